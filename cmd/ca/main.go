@@ -8,11 +8,6 @@ import (
 	"log"
 
 	"mad-ca/internal/app"
-	"mad-ca/internal/core"
-	_ "mad-ca/internal/sims/briansbrain"
-	_ "mad-ca/internal/sims/ecology"
-	_ "mad-ca/internal/sims/elementary"
-	_ "mad-ca/internal/sims/life"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -22,13 +17,17 @@ func main() {
 	cfg.Bind(flag.CommandLine)
 	flag.Parse()
 
-	factory, ok := core.Sims()[cfg.Sim]
-	if !ok {
-		log.Fatalf("unknown sim %q", cfg.Sim)
+	sim, err := newSim(cfg)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	sim := factory(nil)
-	sim.Reset(cfg.Seed)
+	if cfg.Headless {
+		if err := app.RunHeadless(sim, cfg); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	game := app.New(sim, cfg.Scale, cfg.Seed)
 	width, height := game.Layout(0, 0)
@@ -3,12 +3,31 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
 	"os"
+
+	"mad-ca/internal/app"
 )
 
 func main() {
-	fmt.Fprintln(os.Stderr, "The GUI build of mad-ca requires the ebiten build tag.")
-	fmt.Fprintln(os.Stderr, "Re-run with `go run -tags ebiten ./cmd/ca` or build with `-tags ebiten`.")
-	os.Exit(2)
+	cfg := app.NewConfig()
+	cfg.Bind(flag.CommandLine)
+	flag.Parse()
+
+	if !cfg.Headless {
+		fmt.Fprintln(os.Stderr, "The GUI build of mad-ca requires the ebiten build tag.")
+		fmt.Fprintln(os.Stderr, "Re-run with `go run -tags ebiten ./cmd/ca` or build with `-tags ebiten`,")
+		fmt.Fprintln(os.Stderr, "or pass -headless to run without a window.")
+		os.Exit(2)
+	}
+
+	sim, err := newSim(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := app.RunHeadless(sim, cfg); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"mad-ca/internal/app"
+	"mad-ca/internal/core"
+	_ "mad-ca/internal/sims/briansbrain"
+	_ "mad-ca/internal/sims/ecology"
+	_ "mad-ca/internal/sims/elementary"
+	_ "mad-ca/internal/sims/life"
+)
+
+// newSim constructs and seeds the simulation selected by cfg.
+func newSim(cfg *app.Config) (core.Sim, error) {
+	factory, ok := core.Sims()[cfg.Sim]
+	if !ok {
+		return nil, fmt.Errorf("unknown sim %q", cfg.Sim)
+	}
+	sim, err := factory(cfg.SimConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Sim, err)
+	}
+	sim.Reset(cfg.Seed)
+	return sim, nil
+}
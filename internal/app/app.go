@@ -3,7 +3,9 @@
 package app
 
 import (
+	"fmt"
 	"image/color"
+	"log"
 	"math"
 	"time"
 
@@ -38,6 +40,8 @@ type Game struct {
 	painter *render.GridPainter
 	overlay *ui.Overlay
 	hud     *ui.HUD
+	metrics *ui.MetricsPanel
+	inspect *ui.Inspector
 
 	onColor  color.Color
 	offColor color.Color
@@ -77,6 +81,8 @@ func New(sim core.Sim, scale int, seed int64) *Game {
 		painter:  gp,
 		overlay:  ui.NewOverlay(sim, scale),
 		hud:      ui.NewHUD(sim, hudWidth),
+		metrics:  ui.NewMetricsPanel(sim, baseWidth+hudWidth),
+		inspect:  ui.NewInspector(sim, scale),
 		onColor:  color.White,
 		offColor: color.Black,
 		scale:    scale,
@@ -92,6 +98,22 @@ func (g *Game) Reset(seed int64) {
 	g.seed = seed
 	g.sim.Reset(seed)
 	g.tickOnce = false
+	g.metrics.Clear()
+}
+
+// savePreset writes the live parameters to a timestamped preset file in the
+// working directory when the simulation supports it.
+func (g *Game) savePreset() {
+	saver, ok := g.sim.(core.PresetSaver)
+	if !ok {
+		return
+	}
+	path := fmt.Sprintf("preset-%d.json", time.Now().Unix())
+	if err := saver.SavePreset(path); err != nil {
+		log.Printf("save preset: %v", err)
+		return
+	}
+	log.Printf("saved preset to %s", path)
 }
 
 // Update handles per-frame logic and advances the simulation.
@@ -114,10 +136,14 @@ func (g *Game) Update() error {
 	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
 		g.Reset(time.Now().UnixNano())
 	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		g.savePreset()
+	}
 
 	if g.overlay != nil {
 		g.overlay.Update()
 	}
+	g.inspect.Update()
 	if g.hud != nil {
 		baseWidth := g.sim.Size().W * g.scale
 		g.hud.Update(baseWidth)
@@ -141,6 +167,7 @@ func (g *Game) Update() error {
 
 	if (!g.paused) || g.tickOnce {
 		g.sim.Step()
+		g.metrics.Record()
 		g.tickOnce = false
 	}
 	return nil
@@ -160,10 +187,12 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		baseWidth := g.sim.Size().W * g.scale
 		g.hud.Draw(screen, baseWidth, g.scale)
 	}
+	g.metrics.Draw(screen, g.sim.Size().H*g.scale)
+	g.inspect.Draw(screen)
 }
 
 // Layout returns the logical screen size.
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	s := g.sim.Size()
-	return s.W*g.scale + g.hudWidth, s.H * g.scale
+	return s.W*g.scale + g.hudWidth, s.H*g.scale + g.metrics.Height()
 }
@@ -1,6 +1,10 @@
 package app
 
-import "flag"
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
 
 // Config represents the command-line parameters for the application.
 type Config struct {
@@ -8,11 +12,33 @@ type Config struct {
 	Scale int
 	TPS   int
 	Seed  int64
+
+	// SimConfig holds key=value overrides passed to the sim factory.
+	SimConfig map[string]string
+
+	Headless    bool
+	Ticks       int
+	DumpEvery   int
+	DumpFields  string
+	DumpDir     string
+	DumpFormat  string
+	MetricsCSV  string
+	ExportMaps  string
+	SavePreset  string
+	ProfileJSON string
 }
 
 // NewConfig returns a Config populated with sensible defaults.
 func NewConfig() *Config {
-	return &Config{Sim: "life", Scale: 3, TPS: 60, Seed: 42}
+	return &Config{
+		Sim:        "life",
+		Scale:      3,
+		TPS:        60,
+		Seed:       42,
+		Ticks:      1000,
+		DumpDir:    "out",
+		DumpFormat: "npz",
+	}
 }
 
 // Bind attaches the configuration to the provided FlagSet.
@@ -21,4 +47,29 @@ func (c *Config) Bind(fs *flag.FlagSet) {
 	fs.IntVar(&c.Scale, "scale", c.Scale, "pixel scale multiplier")
 	fs.IntVar(&c.TPS, "tps", c.TPS, "ticks per second")
 	fs.Int64Var(&c.Seed, "seed", c.Seed, "seed for simulation reset")
+	fs.Func("set", "sim config override as key=value (repeatable), e.g. -set terrain=noise", c.setSimConfig)
+
+	fs.BoolVar(&c.Headless, "headless", c.Headless, "run without a window for -ticks steps")
+	fs.IntVar(&c.Ticks, "ticks", c.Ticks, "number of steps to run in headless mode")
+	fs.IntVar(&c.DumpEvery, "dump-every", c.DumpEvery, "headless: dump fields every K ticks (0 disables)")
+	fs.StringVar(&c.DumpFields, "dump-fields", c.DumpFields, "headless: comma-separated field keys to dump (empty dumps all)")
+	fs.StringVar(&c.DumpDir, "dump-dir", c.DumpDir, "headless: directory for field dumps")
+	fs.StringVar(&c.DumpFormat, "dump-format", c.DumpFormat, "headless: dump format, npz (one bundle per dump) or npy (one file per field)")
+	fs.StringVar(&c.MetricsCSV, "metrics-csv", c.MetricsCSV, "headless: write per-tick metrics to this CSV file")
+	fs.StringVar(&c.ExportMaps, "export-maps", c.ExportMaps, "headless: write the final state's map layers as PNG files into this directory")
+	fs.StringVar(&c.SavePreset, "save-preset", c.SavePreset, "headless: write the final parameters as a preset file to this path")
+	fs.StringVar(&c.ProfileJSON, "profile-json", c.ProfileJSON, "headless: write per-phase step timings as JSON to this path")
+}
+
+func (c *Config) setSimConfig(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	if c.SimConfig == nil {
+		c.SimConfig = map[string]string{}
+	}
+	c.SimConfig[key] = strings.TrimSpace(val)
+	return nil
 }
@@ -0,0 +1,359 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mad-ca/internal/core"
+	"mad-ca/pkg/caio"
+)
+
+// RunHeadless advances sim for cfg.Ticks steps without opening a window. When
+// configured it dumps the selected fields every cfg.DumpEvery ticks (including
+// the initial state), records per-tick metrics to cfg.MetricsCSV, and exports
+// the final map layers to cfg.ExportMaps, the final parameters to
+// cfg.SavePreset, and the step phase timings to cfg.ProfileJSON.
+func RunHeadless(sim core.Sim, cfg *Config) error {
+	if cfg.Ticks < 0 {
+		return fmt.Errorf("ticks must be non-negative, got %d", cfg.Ticks)
+	}
+	var exporter core.MapExporter
+	if cfg.ExportMaps != "" {
+		var ok bool
+		if exporter, ok = sim.(core.MapExporter); !ok {
+			return fmt.Errorf("sim %q cannot export maps", sim.Name())
+		}
+	}
+	var saver core.PresetSaver
+	if cfg.SavePreset != "" {
+		var ok bool
+		if saver, ok = sim.(core.PresetSaver); !ok {
+			return fmt.Errorf("sim %q cannot save presets", sim.Name())
+		}
+	}
+	var profiler core.StepProfiler
+	if cfg.ProfileJSON != "" {
+		var ok bool
+		if profiler, ok = sim.(core.StepProfiler); !ok {
+			return fmt.Errorf("sim %q does not profile its steps", sim.Name())
+		}
+	}
+	dumper, err := newFieldDumper(sim, cfg)
+	if err != nil {
+		return err
+	}
+	recorder, err := newMetricsRecorder(sim, cfg.MetricsCSV)
+	if err != nil {
+		return err
+	}
+
+	for tick := 0; ; tick++ {
+		if err := recorder.record(tick); err != nil {
+			recorder.close()
+			return err
+		}
+		if dumper != nil && tick%cfg.DumpEvery == 0 {
+			if err := dumper.dump(tick); err != nil {
+				recorder.close()
+				return err
+			}
+		}
+		if tick == cfg.Ticks {
+			break
+		}
+		sim.Step()
+	}
+	if err := recorder.close(); err != nil {
+		return err
+	}
+	if exporter != nil {
+		if err := exporter.ExportMaps(cfg.ExportMaps); err != nil {
+			return err
+		}
+	}
+	if saver != nil {
+		if err := saver.SavePreset(cfg.SavePreset); err != nil {
+			return err
+		}
+	}
+	if profiler != nil {
+		return writeProfile(cfg.ProfileJSON, sim.Name(), cfg.Ticks, profiler.PhaseTimings())
+	}
+	return nil
+}
+
+// phaseReport is one phase of the -profile-json output. Times are in
+// milliseconds: MeanMS over the whole run, RollingMS over the sim's recent
+// window, and Share the fraction of the run's step time.
+type phaseReport struct {
+	Name      string  `json:"name"`
+	TotalMS   float64 `json:"total_ms"`
+	MeanMS    float64 `json:"mean_ms"`
+	RollingMS float64 `json:"rolling_ms"`
+	LastMS    float64 `json:"last_ms"`
+	Share     float64 `json:"share"`
+}
+
+type profileReport struct {
+	Sim         string        `json:"sim"`
+	Ticks       int           `json:"ticks"`
+	TotalMS     float64       `json:"total_ms"`
+	MeanTickMS  float64       `json:"mean_tick_ms"`
+	TicksPerSec float64       `json:"ticks_per_second"`
+	Phases      []phaseReport `json:"phases"`
+}
+
+// writeProfile summarises the run's phase timings as indented JSON.
+func writeProfile(path, name string, ticks int, timings []core.PhaseTiming) error {
+	report := profileReport{Sim: name, Ticks: ticks, Phases: make([]phaseReport, len(timings))}
+	var total time.Duration
+	for _, timing := range timings {
+		total += timing.Total
+	}
+	report.TotalMS = milliseconds(total)
+	if ticks > 0 {
+		report.MeanTickMS = report.TotalMS / float64(ticks)
+	}
+	if total > 0 {
+		report.TicksPerSec = float64(ticks) / total.Seconds()
+	}
+	for i, timing := range timings {
+		phase := phaseReport{
+			Name:      timing.Name,
+			TotalMS:   milliseconds(timing.Total),
+			RollingMS: milliseconds(timing.Average),
+			LastMS:    milliseconds(timing.Last),
+		}
+		if ticks > 0 {
+			phase.MeanMS = phase.TotalMS / float64(ticks)
+		}
+		if total > 0 {
+			phase.Share = float64(timing.Total) / float64(total)
+		}
+		report.Phases[i] = phase
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return writeFile(path, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	})
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+type fieldDumper struct {
+	sim    core.Sim
+	fields core.FieldProvider
+	infos  []core.FieldInfo
+	dir    string
+	format string
+
+	bytes  []uint8
+	shorts []int16
+}
+
+func newFieldDumper(sim core.Sim, cfg *Config) (*fieldDumper, error) {
+	if cfg.DumpEvery <= 0 {
+		return nil, nil
+	}
+	provider, ok := sim.(core.FieldProvider)
+	if !ok {
+		return nil, fmt.Errorf("sim %q does not export fields", sim.Name())
+	}
+	if cfg.DumpFormat != "npz" && cfg.DumpFormat != "npy" {
+		return nil, fmt.Errorf("unknown dump format %q (want npz or npy)", cfg.DumpFormat)
+	}
+
+	available := provider.Fields()
+	var infos []core.FieldInfo
+	if strings.TrimSpace(cfg.DumpFields) == "" {
+		infos = available
+	} else {
+		for _, key := range strings.Split(cfg.DumpFields, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				continue
+			}
+			found := false
+			for _, info := range available {
+				if info.Key == key {
+					infos = append(infos, info)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("sim %q has no field %q", sim.Name(), key)
+			}
+		}
+	}
+	if err := os.MkdirAll(cfg.DumpDir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fieldDumper{sim: sim, fields: provider, infos: infos, dir: cfg.DumpDir, format: cfg.DumpFormat}, nil
+}
+
+func (d *fieldDumper) dump(tick int) error {
+	prefix := fmt.Sprintf("%s_t%06d", d.sim.Name(), tick)
+	if d.format == "npy" {
+		for _, info := range d.infos {
+			path := filepath.Join(d.dir, prefix+"_"+info.Key+".npy")
+			if err := writeFile(path, func(w io.Writer) error { return d.writeField(w, info) }); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return writeFile(filepath.Join(d.dir, prefix+".npz"), func(w io.Writer) error {
+		z := caio.NewNPZWriter(w)
+		for _, info := range d.infos {
+			member, err := z.Create(info.Key)
+			if err != nil {
+				return err
+			}
+			if err := d.writeField(member, info); err != nil {
+				return err
+			}
+		}
+		return z.Close()
+	})
+}
+
+// writeField writes one field using the narrowest NumPy dtype that holds its
+// native type: bytes for uint8/bool layers, int16 for signed integers, and
+// float32 otherwise.
+func (d *fieldDumper) writeField(w io.Writer, info core.FieldInfo) error {
+	size := d.sim.Size()
+	shape := []int{size.H, size.W}
+	values := d.fields.Field(info.Key)
+	switch info.Type {
+	case core.FieldTypeUint8, core.FieldTypeBool:
+		d.bytes = resize(d.bytes, len(values))
+		for i, v := range values {
+			d.bytes[i] = uint8(v)
+		}
+		return caio.WriteNPY(w, shape, d.bytes)
+	case core.FieldTypeInt8, core.FieldTypeInt16:
+		d.shorts = resize(d.shorts, len(values))
+		for i, v := range values {
+			d.shorts[i] = int16(v)
+		}
+		return caio.WriteNPY(w, shape, d.shorts)
+	default:
+		return caio.WriteNPY(w, shape, values)
+	}
+}
+
+type metricsRecorder struct {
+	provider core.MetricsProvider
+	file     *os.File
+	buf      *bufio.Writer
+	series   *caio.SeriesWriter
+	keys     []string
+	values   []float64
+}
+
+func newMetricsRecorder(sim core.Sim, path string) (*metricsRecorder, error) {
+	if path == "" {
+		return nil, nil
+	}
+	provider, ok := sim.(core.MetricsProvider)
+	if !ok {
+		return nil, fmt.Errorf("sim %q does not report metrics", sim.Name())
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsRecorder{provider: provider, file: file, buf: bufio.NewWriter(file)}, nil
+}
+
+// record appends the current snapshot. The column set is fixed by the first
+// snapshot; samples that disappear later are written as empty cells.
+func (r *metricsRecorder) record(tick int) error {
+	if r == nil {
+		return nil
+	}
+	snapshot := r.provider.MetricsSnapshot()
+	if r.series == nil {
+		for _, sample := range snapshot.Samples {
+			r.keys = append(r.keys, sample.Key)
+		}
+		series, err := caio.NewSeriesWriter(r.buf, "tick", r.keys)
+		if err != nil {
+			return err
+		}
+		r.series = series
+		r.values = make([]float64, len(r.keys))
+	}
+	for i, key := range r.keys {
+		r.values[i] = math.NaN()
+		for _, sample := range snapshot.Samples {
+			if sample.Key == key {
+				r.values[i] = sample.Value
+				break
+			}
+		}
+	}
+	return r.series.WriteRow(int64(tick), r.values)
+}
+
+func (r *metricsRecorder) close() error {
+	if r == nil {
+		return nil
+	}
+	var err error
+	if r.series != nil {
+		err = r.series.Flush()
+	}
+	if flushErr := r.buf.Flush(); err == nil {
+		err = flushErr
+	}
+	if closeErr := r.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	buf := bufio.NewWriter(file)
+	if err := write(buf); err != nil {
+		file.Close()
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func resize[T any](buf []T, n int) []T {
+	if cap(buf) < n {
+		return make([]T, n)
+	}
+	return buf[:n]
+}
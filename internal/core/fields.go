@@ -0,0 +1,108 @@
+package core
+
+// FieldType records the native element type of a field before it was
+// converted to float32 for consumers.
+type FieldType string
+
+const (
+	// FieldTypeUint8 denotes unsigned byte fields such as enums and counters.
+	FieldTypeUint8 FieldType = "uint8"
+	// FieldTypeInt8 denotes signed byte fields such as headings.
+	FieldTypeInt8 FieldType = "int8"
+	// FieldTypeInt16 denotes 16-bit integer fields such as elevation.
+	FieldTypeInt16 FieldType = "int16"
+	// FieldTypeFloat32 denotes continuous fields.
+	FieldTypeFloat32 FieldType = "float32"
+	// FieldTypeBool denotes flag fields stored as 0 or 1.
+	FieldTypeBool FieldType = "bool"
+)
+
+// FieldInfo describes a named per-cell layer a simulation can export.
+type FieldInfo struct {
+	Key   string
+	Label string
+	Type  FieldType
+	Units string
+
+	// Min and Max bound the expected values. When Min >= Max the range is
+	// data-dependent and consumers derive it from the values.
+	Min float32
+	Max float32
+
+	// Colormap names the preferred colour ramp; consumers fall back to a
+	// default when the name is unknown.
+	Colormap string
+
+	// Dense marks layers that carry meaningful values everywhere (terrain
+	// height, for example) rather than sparse intensities where the minimum
+	// means "nothing here".
+	Dense bool
+}
+
+// FieldProvider exposes named per-cell layers as row-major float32 slices.
+// The slice returned by Field may alias simulation state or a reused scratch
+// buffer: callers must not modify it and should copy it if they need it past
+// the next Step or Field call. Field returns nil for unknown keys.
+type FieldProvider interface {
+	Fields() []FieldInfo
+	Field(key string) []float32
+}
+
+// FieldSampler is implemented by field providers that can read a single
+// cell of a layer without converting the whole grid, which keeps per-cell
+// readers such as the hover inspector cheap on large worlds. FieldAt reports
+// false for unknown keys and positions outside the grid.
+type FieldSampler interface {
+	FieldAt(key string, x, y int) (float32, bool)
+}
+
+// FieldFromUint8 converts src into dst, reallocating dst when its length
+// differs, and returns the result.
+func FieldFromUint8[T ~uint8](dst []float32, src []T) []float32 {
+	dst = resizeField(dst, len(src))
+	for i, v := range src {
+		dst[i] = float32(v)
+	}
+	return dst
+}
+
+// FieldFromInt8 converts src into dst, reallocating dst when its length
+// differs, and returns the result.
+func FieldFromInt8(dst []float32, src []int8) []float32 {
+	dst = resizeField(dst, len(src))
+	for i, v := range src {
+		dst[i] = float32(v)
+	}
+	return dst
+}
+
+// FieldFromInt16 converts src into dst, reallocating dst when its length
+// differs, and returns the result.
+func FieldFromInt16(dst []float32, src []int16) []float32 {
+	dst = resizeField(dst, len(src))
+	for i, v := range src {
+		dst[i] = float32(v)
+	}
+	return dst
+}
+
+// FieldFromBool converts src into dst as 0/1 values, reallocating dst when
+// its length differs, and returns the result.
+func FieldFromBool(dst []float32, src []bool) []float32 {
+	dst = resizeField(dst, len(src))
+	for i, v := range src {
+		if v {
+			dst[i] = 1
+		} else {
+			dst[i] = 0
+		}
+	}
+	return dst
+}
+
+func resizeField(dst []float32, n int) []float32 {
+	if len(dst) != n {
+		return make([]float32, n)
+	}
+	return dst
+}
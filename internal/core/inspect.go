@@ -0,0 +1,20 @@
+package core
+
+// NamedValue is a labelled, display-ready reading.
+type NamedValue struct {
+	Name  string
+	Value string
+}
+
+// CellInspector exposes per-cell details for the hover inspector. The
+// coordinates are in cell units; implementations return nil for positions
+// outside the grid.
+type CellInspector interface {
+	InspectCell(x, y int) []NamedValue
+}
+
+// StatusProvider exposes a few global readings (a clock, a mode) that the
+// HUD pins above the parameter controls.
+type StatusProvider interface {
+	Status() []NamedValue
+}
@@ -0,0 +1,8 @@
+package core
+
+// MapExporter is implemented by simulations that can write their editable
+// layers as image files, so a run's state can be changed externally and
+// loaded again through the sim's config.
+type MapExporter interface {
+	ExportMaps(dir string) error
+}
@@ -0,0 +1,29 @@
+package core
+
+// MetricSample is a single scalar reading captured for the current tick.
+// Samples that share a Group are plotted together on the same chart.
+type MetricSample struct {
+	Key   string
+	Label string
+	Group string
+	Value float64
+}
+
+// MetricHistogram is a distribution captured for the current tick. Bins[i]
+// holds the count for bucket i; the meaning of the index is sim-defined.
+type MetricHistogram struct {
+	Key   string
+	Label string
+	Bins  []int
+}
+
+// MetricsSnapshot bundles the telemetry a simulation reports for one tick.
+type MetricsSnapshot struct {
+	Samples    []MetricSample
+	Histograms []MetricHistogram
+}
+
+// MetricsProvider exposes per-tick telemetry for charting and export.
+type MetricsProvider interface {
+	MetricsSnapshot() MetricsSnapshot
+}
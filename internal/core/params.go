@@ -10,6 +10,8 @@ const (
 	ParamTypeFloat ParamType = "float"
 	// ParamTypeBool denotes boolean parameters.
 	ParamTypeBool ParamType = "bool"
+	// ParamTypeString denotes free-form or enumerated string parameters.
+	ParamTypeString ParamType = "string"
 )
 
 // Parameter describes a single tunable value exposed by a simulation.
@@ -47,6 +49,13 @@ type ParameterControl struct {
 	Max    float64
 	HasMin bool
 	HasMax bool
+
+	// Percent marks a 0–1 probability shown and edited as 0–100%; the
+	// setter receives the percentage.
+	Percent bool
+	// Options lists the choices for a string control, which the HUD cycles
+	// through.
+	Options []string
 }
 
 // ParameterControlsProvider exposes the list of HUD-adjustable controls.
@@ -64,3 +73,21 @@ type IntParameterSetter interface {
 type FloatParameterSetter interface {
 	SetFloatParameter(key string, value float64) bool
 }
+
+// BoolParameterSetter allows HUD interactions to switch boolean parameters
+// on or off.
+type BoolParameterSetter interface {
+	SetBoolParameter(key string, value bool) bool
+}
+
+// StringParameterSetter allows HUD interactions to pick a string parameter
+// from its control's options.
+type StringParameterSetter interface {
+	SetStringParameter(key string, value string) bool
+}
+
+// PresetSaver is implemented by simulations that can write their live
+// parameters as a preset file loadable through their config.
+type PresetSaver interface {
+	SavePreset(path string) error
+}
@@ -0,0 +1,22 @@
+package core
+
+import "time"
+
+// PhaseTiming reports the wall-clock time one phase of a simulation step
+// takes.
+type PhaseTiming struct {
+	Name string
+
+	// Last is the time spent in the most recent tick, Average the mean over
+	// a rolling window of recent ticks, and Total the time accumulated since
+	// the last reset.
+	Last    time.Duration
+	Average time.Duration
+	Total   time.Duration
+}
+
+// StepProfiler exposes per-phase step timings, in step order, so callers
+// can see which part of a tick dominates.
+type StepProfiler interface {
+	PhaseTimings() []PhaseTiming
+}
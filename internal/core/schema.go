@@ -0,0 +1,464 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ParamSpec describes one tunable declared in a ParamSchema.
+type ParamSpec struct {
+	Key   string
+	Label string
+	Group string
+	Type  ParamType
+
+	Min    float64
+	Max    float64
+	HasMin bool
+	HasMax bool
+
+	// Step is the HUD increment; zero lets the HUD pick one from the range.
+	Step float64
+	// Floor names a parameter this one may never drop below, pairing a
+	// maximum with its minimum.
+	Floor string
+	// Percent marks a 0–1 probability that the HUD edits as 0–100%.
+	Percent bool
+	// Clamp makes lenient parsing clamp out-of-range values instead of
+	// discarding them.
+	Clamp bool
+	// Control exposes the parameter as a HUD control.
+	Control bool
+
+	field int
+}
+
+// ParamSchema derives config parsing, validation, parameter snapshots, HUD
+// controls, and setters from the field tags of a sim's tunables struct, so
+// every parameter is declared exactly once:
+//
+//	param  config key; untagged fields are not part of the schema
+//	label  display label, defaulting to the key
+//	group  snapshot group, carried over to the following fields
+//	min    inclusive lower bound
+//	max    inclusive upper bound
+//	step   HUD step size
+//	floor  key of a parameter this one may not drop below
+//	opts   comma-separated flags: percent, clamp, hud
+//
+// The percent option implies bounds of 0 and 1 and clamping. Supported field
+// kinds are int, float64, bool, and string.
+type ParamSchema struct {
+	typ   reflect.Type
+	specs []ParamSpec
+	index map[string]int
+}
+
+// NewParamSchema builds a schema from the tagged fields of prototype, which
+// must be a struct value.
+func NewParamSchema(prototype any) (*ParamSchema, error) {
+	typ := reflect.TypeOf(prototype)
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("param schema: %T is not a struct", prototype)
+	}
+	s := &ParamSchema{typ: typ, index: map[string]int{}}
+	group := ""
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		key, ok := field.Tag.Lookup("param")
+		if !ok {
+			continue
+		}
+		if _, dup := s.index[key]; dup || key == "" {
+			return nil, fmt.Errorf("param schema: field %s has a missing or duplicate key %q", field.Name, key)
+		}
+		if g, ok := field.Tag.Lookup("group"); ok {
+			group = g
+		}
+		spec := ParamSpec{Key: key, Label: field.Tag.Get("label"), Group: group, field: i}
+		if spec.Label == "" {
+			spec.Label = key
+		}
+		switch field.Type.Kind() {
+		case reflect.Int:
+			spec.Type = ParamTypeInt
+		case reflect.Float64:
+			spec.Type = ParamTypeFloat
+		case reflect.Bool:
+			spec.Type = ParamTypeBool
+		case reflect.String:
+			spec.Type = ParamTypeString
+		default:
+			return nil, fmt.Errorf("param schema: %s has unsupported kind %s", key, field.Type.Kind())
+		}
+		for _, opt := range strings.Split(field.Tag.Get("opts"), ",") {
+			switch strings.TrimSpace(opt) {
+			case "":
+			case "percent":
+				spec.Percent = true
+				spec.Clamp = true
+				spec.Min, spec.Max, spec.HasMin, spec.HasMax = 0, 1, true, true
+			case "clamp":
+				spec.Clamp = true
+			case "hud":
+				spec.Control = true
+			default:
+				return nil, fmt.Errorf("param schema: %s has unknown option %q", key, opt)
+			}
+		}
+		for _, bound := range []struct {
+			tag string
+			val *float64
+			has *bool
+		}{{"min", &spec.Min, &spec.HasMin}, {"max", &spec.Max, &spec.HasMax}, {"step", &spec.Step, nil}} {
+			raw, ok := field.Tag.Lookup(bound.tag)
+			if !ok {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("param schema: %s has invalid %s %q", key, bound.tag, raw)
+			}
+			*bound.val = parsed
+			if bound.has != nil {
+				*bound.has = true
+			}
+		}
+		spec.Floor = field.Tag.Get("floor")
+		s.index[key] = len(s.specs)
+		s.specs = append(s.specs, spec)
+	}
+	for _, spec := range s.specs {
+		if spec.Floor == "" {
+			continue
+		}
+		if lower, ok := s.Spec(spec.Floor); !ok || lower.Type != spec.Type {
+			return nil, fmt.Errorf("param schema: %s has floor %q that is not a parameter of the same type", spec.Key, spec.Floor)
+		}
+	}
+	return s, nil
+}
+
+// MustParamSchema is like NewParamSchema but panics on malformed tags. It is
+// meant for package-level schema variables.
+func MustParamSchema(prototype any) *ParamSchema {
+	s, err := NewParamSchema(prototype)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Specs lists the schema's parameters in declaration order.
+func (s *ParamSchema) Specs() []ParamSpec {
+	return append([]ParamSpec(nil), s.specs...)
+}
+
+// Spec looks up a parameter by key.
+func (s *ParamSchema) Spec(key string) (ParamSpec, bool) {
+	i, ok := s.index[key]
+	if !ok {
+		return ParamSpec{}, false
+	}
+	return s.specs[i], true
+}
+
+// Apply leniently copies values for known keys from cfg into dst, a pointer
+// to the schema's struct. Unparsable values are ignored, out-of-range values
+// are clamped or ignored according to the spec, and floors are enforced.
+func (s *ParamSchema) Apply(dst any, cfg map[string]string) {
+	v := s.value(dst, true)
+	for _, spec := range s.specs {
+		raw, ok := cfg[spec.Key]
+		if !ok {
+			continue
+		}
+		if parsed, err := spec.parse(raw); err == nil {
+			if spec.outOfRange(parsed) {
+				if !spec.Clamp {
+					continue
+				}
+				parsed = spec.clamp(parsed)
+			}
+			spec.store(v.Field(spec.field), parsed, raw)
+		}
+	}
+	s.enforceFloors(v)
+}
+
+// Parse strictly copies values for known keys from cfg into dst and reports
+// every value that fails to parse or falls out of range, plus any violated
+// floor. Valid values are still applied.
+func (s *ParamSchema) Parse(dst any, cfg map[string]string) error {
+	v := s.value(dst, true)
+	var errs []error
+	for _, spec := range s.specs {
+		raw, ok := cfg[spec.Key]
+		if !ok {
+			continue
+		}
+		parsed, err := spec.parse(raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid %s %q", spec.Key, spec.Type, raw))
+			continue
+		}
+		if spec.outOfRange(parsed) {
+			errs = append(errs, fmt.Errorf("%s: %s is outside %s", spec.Key, raw, spec.rangeString()))
+			continue
+		}
+		spec.store(v.Field(spec.field), parsed, raw)
+	}
+	if err := s.checkFloors(v); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Validate reports every parameter in src that is out of range or below its
+// floor. src may be the struct or a pointer to it.
+func (s *ParamSchema) Validate(src any) error {
+	v := s.value(src, false)
+	var errs []error
+	for _, spec := range s.specs {
+		if spec.Type != ParamTypeInt && spec.Type != ParamTypeFloat {
+			continue
+		}
+		if value := number(v.Field(spec.field)); spec.outOfRange(value) {
+			errs = append(errs, fmt.Errorf("%s: %s is outside %s", spec.Key, spec.format(v.Field(spec.field)), spec.rangeString()))
+		}
+	}
+	if err := s.checkFloors(v); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Groups snapshots the current values in src grouped as declared.
+func (s *ParamSchema) Groups(src any) []ParameterGroup {
+	v := s.value(src, false)
+	var groups []ParameterGroup
+	for _, spec := range s.specs {
+		if len(groups) == 0 || groups[len(groups)-1].Name != spec.Group {
+			groups = append(groups, ParameterGroup{Name: spec.Group})
+		}
+		last := &groups[len(groups)-1]
+		last.Params = append(last.Params, Parameter{
+			Key:   spec.Key,
+			Label: spec.Label,
+			Type:  spec.Type,
+			Value: spec.format(v.Field(spec.field)),
+		})
+	}
+	return groups
+}
+
+// Controls lists the HUD controls for parameters tagged with the hud option.
+// Percent parameters are presented on a 0–100 scale.
+func (s *ParamSchema) Controls() []ParameterControl {
+	var controls []ParameterControl
+	for _, spec := range s.specs {
+		if !spec.Control {
+			continue
+		}
+		ctrl := ParameterControl{
+			Key:     spec.Key,
+			Label:   spec.Label,
+			Type:    spec.Type,
+			Step:    spec.Step,
+			Min:     spec.Min,
+			Max:     spec.Max,
+			HasMin:  spec.HasMin,
+			HasMax:  spec.HasMax,
+			Percent: spec.Percent,
+		}
+		if spec.Percent {
+			ctrl.Min, ctrl.Max = 0, 100
+		}
+		if spec.Type == ParamTypeInt && ctrl.Step == 0 {
+			ctrl.Step = 1
+		}
+		controls = append(controls, ctrl)
+	}
+	return controls
+}
+
+// SetInt updates an int parameter in dst, clamped to its bounds and kept
+// consistent with any floor pairing. It reports false for unknown keys and
+// non-int parameters.
+func (s *ParamSchema) SetInt(dst any, key string, value int) bool {
+	spec, ok := s.Spec(key)
+	if !ok || spec.Type != ParamTypeInt {
+		return false
+	}
+	s.set(s.value(dst, true), spec, float64(value))
+	return true
+}
+
+// SetFloat updates a float parameter in dst. Percent parameters take their
+// value on the 0–100 scale used by their HUD control.
+func (s *ParamSchema) SetFloat(dst any, key string, value float64) bool {
+	spec, ok := s.Spec(key)
+	if !ok || spec.Type != ParamTypeFloat {
+		return false
+	}
+	if spec.Percent {
+		value /= 100
+	}
+	s.set(s.value(dst, true), spec, value)
+	return true
+}
+
+// SetBool updates a bool parameter in dst. It reports false for unknown keys
+// and non-bool parameters.
+func (s *ParamSchema) SetBool(dst any, key string, value bool) bool {
+	spec, ok := s.Spec(key)
+	if !ok || spec.Type != ParamTypeBool {
+		return false
+	}
+	spec.store(s.value(dst, true).Field(spec.field), 0, strconv.FormatBool(value))
+	return true
+}
+
+func (s *ParamSchema) set(v reflect.Value, spec ParamSpec, value float64) {
+	value = spec.clamp(value)
+	if spec.Floor != "" {
+		lower, _ := s.Spec(spec.Floor)
+		value = math.Max(value, number(v.Field(lower.field)))
+	}
+	for _, upper := range s.specs {
+		if upper.Floor == spec.Key {
+			value = math.Min(value, number(v.Field(upper.field)))
+		}
+	}
+	spec.store(v.Field(spec.field), value, "")
+}
+
+func (s *ParamSchema) enforceFloors(v reflect.Value) {
+	for _, spec := range s.specs {
+		if spec.Floor == "" {
+			continue
+		}
+		lower, _ := s.Spec(spec.Floor)
+		if floor := number(v.Field(lower.field)); number(v.Field(spec.field)) < floor {
+			spec.store(v.Field(spec.field), floor, "")
+		}
+	}
+}
+
+func (s *ParamSchema) checkFloors(v reflect.Value) error {
+	var errs []error
+	for _, spec := range s.specs {
+		if spec.Floor == "" {
+			continue
+		}
+		lower, _ := s.Spec(spec.Floor)
+		if number(v.Field(spec.field)) < number(v.Field(lower.field)) {
+			errs = append(errs, fmt.Errorf("%s: %s is below %s %s", spec.Key, spec.format(v.Field(spec.field)), lower.Key, lower.format(v.Field(lower.field))))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// value unwraps target to the schema's struct, requiring a pointer when the
+// caller needs to write to it.
+func (s *ParamSchema) value(target any, writable bool) reflect.Value {
+	v := reflect.ValueOf(target)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	} else if writable {
+		panic(fmt.Sprintf("param schema: %T is not a pointer", target))
+	}
+	if v.Type() != s.typ {
+		panic(fmt.Sprintf("param schema: %s does not match %s", v.Type(), s.typ))
+	}
+	return v
+}
+
+// parse converts raw to a number for int and float specs; bool and string
+// values are validated here and stored from raw.
+func (spec ParamSpec) parse(raw string) (float64, error) {
+	raw = strings.TrimSpace(raw)
+	switch spec.Type {
+	case ParamTypeInt:
+		parsed, err := strconv.Atoi(raw)
+		return float64(parsed), err
+	case ParamTypeFloat:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err == nil && (math.IsNaN(parsed) || math.IsInf(parsed, 0)) {
+			err = strconv.ErrRange
+		}
+		return parsed, err
+	case ParamTypeBool:
+		_, err := strconv.ParseBool(raw)
+		return 0, err
+	default:
+		return 0, nil
+	}
+}
+
+func (spec ParamSpec) outOfRange(value float64) bool {
+	return (spec.HasMin && value < spec.Min) || (spec.HasMax && value > spec.Max)
+}
+
+func (spec ParamSpec) clamp(value float64) float64 {
+	if spec.HasMin && value < spec.Min {
+		value = spec.Min
+	}
+	if spec.HasMax && value > spec.Max {
+		value = spec.Max
+	}
+	return value
+}
+
+func (spec ParamSpec) rangeString() string {
+	lo, hi := "-inf", "+inf"
+	if spec.HasMin {
+		lo = strconv.FormatFloat(spec.Min, 'f', -1, 64)
+	}
+	if spec.HasMax {
+		hi = strconv.FormatFloat(spec.Max, 'f', -1, 64)
+	}
+	return "[" + lo + ", " + hi + "]"
+}
+
+func (spec ParamSpec) store(field reflect.Value, value float64, raw string) {
+	switch spec.Type {
+	case ParamTypeInt:
+		field.SetInt(int64(math.Round(value)))
+	case ParamTypeFloat:
+		field.SetFloat(value)
+	case ParamTypeBool:
+		parsed, _ := strconv.ParseBool(strings.TrimSpace(raw))
+		field.SetBool(parsed)
+	case ParamTypeString:
+		field.SetString(raw)
+	}
+}
+
+func (spec ParamSpec) format(field reflect.Value) string {
+	switch spec.Type {
+	case ParamTypeInt:
+		return strconv.FormatInt(field.Int(), 10)
+	case ParamTypeFloat:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64)
+	case ParamTypeBool:
+		return strconv.FormatBool(field.Bool())
+	default:
+		return field.String()
+	}
+}
+
+func number(field reflect.Value) float64 {
+	switch field.Kind() {
+	case reflect.Int:
+		return float64(field.Int())
+	case reflect.Float64:
+		return field.Float()
+	default:
+		return 0
+	}
+}
@@ -15,8 +15,9 @@ type Sim interface {
 	Cells() []uint8
 }
 
-// Factory constructs a Sim using an optional configuration map.
-type Factory func(cfg map[string]string) Sim
+// Factory constructs a Sim using an optional configuration map. It returns an
+// error when the configuration references resources that cannot be loaded.
+type Factory func(cfg map[string]string) (Sim, error)
 
 var sims = map[string]Factory{}
 
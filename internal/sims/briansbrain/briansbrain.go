@@ -13,6 +13,8 @@ type Brain struct {
 	w, h int
 	cur  []uint8
 	nxt  []uint8
+
+	field []float32
 }
 
 // New creates a Brain simulation with the provided dimensions.
@@ -78,8 +80,43 @@ func (b *Brain) Step() {
 	b.cur, b.nxt = b.nxt, b.cur
 }
 
+// MetricsSnapshot reports how many cells are firing and refractory.
+func (b *Brain) MetricsSnapshot() core.MetricsSnapshot {
+	firing, dying := 0, 0
+	for _, c := range b.cur {
+		switch c {
+		case stateOn:
+			firing++
+		case stateDying:
+			dying++
+		}
+	}
+	return core.MetricsSnapshot{
+		Samples: []core.MetricSample{
+			{Key: "firing", Label: "Firing", Group: "Activity", Value: float64(firing)},
+			{Key: "dying", Label: "Dying", Group: "Activity", Value: float64(dying)},
+		},
+	}
+}
+
+// Fields lists the exported per-cell layers.
+func (b *Brain) Fields() []core.FieldInfo {
+	return []core.FieldInfo{
+		{Key: "state", Label: "State", Type: core.FieldTypeUint8, Min: stateDead, Max: stateDying, Colormap: "viridis", Dense: true},
+	}
+}
+
+// Field returns the named layer as float32 values.
+func (b *Brain) Field(key string) []float32 {
+	if key != "state" {
+		return nil
+	}
+	b.field = core.FieldFromUint8(b.field, b.cur)
+	return b.field
+}
+
 func init() {
-	core.Register("briansbrain", func(cfg map[string]string) core.Sim {
-		return New(256, 256)
+	core.Register("briansbrain", func(cfg map[string]string) (core.Sim, error) {
+		return New(256, 256), nil
 	})
 }
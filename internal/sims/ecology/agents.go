@@ -0,0 +1,402 @@
+package ecology
+
+import "strconv"
+
+// AgentKind enumerates the animal species roaming the ecology world.
+type AgentKind uint8
+
+const (
+	AgentNone AgentKind = iota
+	AgentHerbivore
+	AgentPredator
+)
+
+// String returns the human-readable species name.
+func (k AgentKind) String() string {
+	switch k {
+	case AgentNone:
+		return "none"
+	case AgentHerbivore:
+		return "herbivore"
+	case AgentPredator:
+		return "predator"
+	default:
+		return "agent(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// Agent is a single animal occupying one tile. Energy is spent every tick and
+// replenished by grazing (herbivores) or hunting (predators); an agent starves
+// when it runs out.
+type Agent struct {
+	Kind   AgentKind
+	X, Y   int
+	Energy float64
+	Age    int
+
+	dead bool
+}
+
+// PopulationMetrics captures agent telemetry for the current tick. Event
+// counters cover the last step only.
+type PopulationMetrics struct {
+	Herbivores int
+	Predators  int
+
+	Births       int
+	Starved      int
+	Eaten        int
+	HazardDeaths int
+	AgeDeaths    int
+}
+
+type agentDeath int
+
+const (
+	agentStarved agentDeath = iota
+	agentEaten
+	agentHazard
+	agentAged
+)
+
+var agentOffsets = [8][2]int{
+	{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1},
+}
+
+// Agents exposes the live agents. The slice is rebuilt every step.
+func (w *World) Agents() []Agent { return w.agents }
+
+// PopulationSummary reports agent counts and the last tick's births and deaths.
+func (w *World) PopulationSummary() PopulationMetrics { return w.population }
+
+// spawnAgents scatters the initial herbivore and predator populations over
+// free vegetated dirt using the world RNG. Counts scale with the number of
+// such tiles so the animals start where there is food.
+func (w *World) spawnAgents() {
+	w.agents = w.agents[:0]
+	for i := range w.agentCell {
+		w.agentCell[i] = 0
+	}
+	params := w.cfg.Params
+	w.scatterAgents(AgentHerbivore, params.HerbivoreDensity, params.HerbivoreReproduceEnergy/2)
+	w.scatterAgents(AgentPredator, params.PredatorDensity, params.PredatorReproduceEnergy/2)
+	w.population = PopulationMetrics{}
+	w.countPopulation()
+}
+
+func (w *World) scatterAgents(kind AgentKind, density float64, energy float64) {
+	if density <= 0 {
+		return
+	}
+	var candidates []int
+	for idx := range w.groundCurr {
+		if !soilGround(w.groundCurr[idx]) || w.vegCurr[idx] == VegetationNone || !w.agentPassable(idx) {
+			continue
+		}
+		candidates = append(candidates, idx)
+	}
+	count := int(density * float64(len(candidates)))
+	for placed := 0; placed < count && len(candidates) > 0; placed++ {
+		pick := w.streams.agents.Intn(len(candidates))
+		idx := candidates[pick]
+		candidates[pick] = candidates[len(candidates)-1]
+		candidates = candidates[:len(candidates)-1]
+		w.addAgent(Agent{Kind: kind, X: idx % w.w, Y: idx / w.w, Energy: energy})
+	}
+}
+
+func (w *World) addAgent(a Agent) {
+	w.agents = append(w.agents, a)
+	w.agentCell[a.Y*w.w+a.X] = int32(len(w.agents))
+}
+
+// agentPassable reports whether an agent may stand on idx: the tile must be
+// free, dry, not lava, and not burning.
+func (w *World) agentPassable(idx int) bool {
+	return w.agentCell[idx] == 0 && w.groundCurr[idx] != GroundWater && !w.agentHazard(idx)
+}
+
+func (w *World) agentHazard(idx int) bool {
+	return w.groundCurr[idx] == GroundLava || w.burnTTL[idx] > 0
+}
+
+// applyAgents advances every agent once in a shuffled order: hazards and
+// starvation are checked first, then each species acts and may reproduce.
+// Herbivores graze vegCurr in place so the vegetation pass sees the cropped
+// tiles in the same tick.
+func (w *World) applyAgents() {
+	w.population = PopulationMetrics{}
+	if len(w.agents) == 0 {
+		return
+	}
+
+	params := w.cfg.Params
+	order := w.streams.agents.Perm(len(w.agents))
+	for _, i := range order {
+		a := &w.agents[i]
+		if a.dead {
+			continue
+		}
+		idx := a.Y*w.w + a.X
+		if w.agentHazard(idx) || w.groundCurr[idx] == GroundWater {
+			w.killAgent(i, agentHazard)
+			continue
+		}
+
+		metabolism, maxAge := params.HerbivoreMetabolism, params.HerbivoreMaxAge
+		if a.Kind == AgentPredator {
+			metabolism, maxAge = params.PredatorMetabolism, params.PredatorMaxAge
+		}
+		a.Age++
+		a.Energy -= metabolism
+		if a.Energy <= 0 {
+			w.killAgent(i, agentStarved)
+			continue
+		}
+		if maxAge > 0 && a.Age > maxAge {
+			w.killAgent(i, agentAged)
+			continue
+		}
+
+		switch a.Kind {
+		case AgentHerbivore:
+			w.stepHerbivore(i)
+		case AgentPredator:
+			w.stepPredator(i)
+		}
+		w.reproduceAgent(i)
+	}
+
+	alive := w.agents[:0]
+	for _, a := range w.agents {
+		if !a.dead {
+			alive = append(alive, a)
+		}
+	}
+	w.agents = alive
+	for i := range w.agentCell {
+		w.agentCell[i] = 0
+	}
+	for i, a := range w.agents {
+		w.agentCell[a.Y*w.w+a.X] = int32(i + 1)
+	}
+	w.countPopulation()
+}
+
+func (w *World) countPopulation() {
+	w.population.Herbivores = 0
+	w.population.Predators = 0
+	for _, a := range w.agents {
+		switch a.Kind {
+		case AgentHerbivore:
+			w.population.Herbivores++
+		case AgentPredator:
+			w.population.Predators++
+		}
+	}
+}
+
+func (w *World) killAgent(i int, cause agentDeath) {
+	a := &w.agents[i]
+	a.dead = true
+	idx := a.Y*w.w + a.X
+	if w.agentCell[idx] == int32(i+1) {
+		w.agentCell[idx] = 0
+	}
+	switch cause {
+	case agentStarved:
+		w.population.Starved++
+	case agentEaten:
+		w.population.Eaten++
+	case agentHazard:
+		w.population.HazardDeaths++
+	case agentAged:
+		w.population.AgeDeaths++
+	}
+}
+
+// stepHerbivore flees nearby fire and lava; otherwise it grazes its tile or
+// walks toward the most nourishing neighbouring vegetation.
+func (w *World) stepHerbivore(i int) {
+	params := &w.cfg.Params
+	a := &w.agents[i]
+
+	if hx, hy, ok := w.hazardCentroid(a.X, a.Y, params.HerbivoreFleeRadius); ok {
+		w.moveAgentBy(i, func(nx, ny int) float64 {
+			dx, dy := w.wrapDelta(float64(nx)-hx, w.w), w.wrapDelta(float64(ny)-hy, w.h)
+			return dx*dx + dy*dy
+		})
+		return
+	}
+
+	idx := a.Y*w.w + a.X
+	switch w.vegCurr[idx] {
+	case VegetationGrass:
+		w.vegCurr[idx] = VegetationNone
+		a.Energy += params.HerbivoreGrassGain
+		return
+	case VegetationShrub:
+		w.vegCurr[idx] = VegetationGrass
+		a.Energy += params.HerbivoreShrubGain
+		return
+	}
+
+	w.moveAgentBy(i, func(nx, ny int) float64 {
+		switch w.vegCurr[ny*w.w+nx] {
+		case VegetationShrub:
+			return 2
+		case VegetationGrass:
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// stepPredator eats an adjacent herbivore when it can, otherwise closes in on
+// the nearest herbivore within its sense radius or wanders.
+func (w *World) stepPredator(i int) {
+	params := &w.cfg.Params
+	a := &w.agents[i]
+
+	start := w.streams.agents.Intn(len(agentOffsets))
+	for k := range agentOffsets {
+		off := agentOffsets[(start+k)%len(agentOffsets)]
+		nIdx, ok := w.tileIndex(a.X+off[0], a.Y+off[1])
+		if !ok {
+			continue
+		}
+		prey := int(w.agentCell[nIdx]) - 1
+		if prey < 0 || w.agents[prey].Kind != AgentHerbivore || w.agents[prey].dead {
+			continue
+		}
+		if w.streams.agents.Float64() >= params.PredatorHuntChance {
+			break
+		}
+		w.killAgent(prey, agentEaten)
+		a.Energy += params.PredatorPreyGain
+		return
+	}
+
+	tx, ty, found := w.nearestHerbivore(a.X, a.Y, params.PredatorSenseRadius)
+	if !found {
+		w.moveAgentBy(i, func(int, int) float64 { return 0 })
+		return
+	}
+	w.moveAgentBy(i, func(nx, ny int) float64 {
+		dx, dy := w.wrapDelta(float64(nx-tx), w.w), w.wrapDelta(float64(ny-ty), w.h)
+		return -(dx*dx + dy*dy)
+	})
+}
+
+// moveAgentBy steps agent i to the passable neighbour with the highest score.
+// A neighbour that merely ties the current tile still wins so idle agents
+// wander; the random starting direction breaks ties without a directional
+// bias while staying deterministic under the world seed.
+func (w *World) moveAgentBy(i int, score func(x, y int) float64) {
+	a := &w.agents[i]
+	best := score(a.X, a.Y)
+	bestX, bestY := a.X, a.Y
+	wander := true
+
+	start := w.streams.agents.Intn(len(agentOffsets))
+	for k := range agentOffsets {
+		off := agentOffsets[(start+k)%len(agentOffsets)]
+		nIdx, ok := w.tileIndex(a.X+off[0], a.Y+off[1])
+		if !ok || !w.agentPassable(nIdx) {
+			continue
+		}
+		nx, ny := nIdx%w.w, nIdx/w.w
+		s := score(nx, ny)
+		if s > best || (wander && s == best) {
+			best, bestX, bestY = s, nx, ny
+			wander = false
+		}
+	}
+	if bestX == a.X && bestY == a.Y {
+		return
+	}
+	w.agentCell[a.Y*w.w+a.X] = 0
+	a.X, a.Y = bestX, bestY
+	w.agentCell[a.Y*w.w+a.X] = int32(i + 1)
+}
+
+// reproduceAgent splits a well-fed agent's energy with an offspring placed on
+// a free neighbouring tile. Offspring join the population next tick.
+func (w *World) reproduceAgent(i int) {
+	params := &w.cfg.Params
+	a := w.agents[i]
+	threshold, chance := params.HerbivoreReproduceEnergy, params.HerbivoreReproduceChance
+	if a.Kind == AgentPredator {
+		threshold, chance = params.PredatorReproduceEnergy, params.PredatorReproduceChance
+	}
+	if a.Energy < threshold || chance <= 0 || w.streams.agents.Float64() >= chance {
+		return
+	}
+
+	start := w.streams.agents.Intn(len(agentOffsets))
+	for k := range agentOffsets {
+		off := agentOffsets[(start+k)%len(agentOffsets)]
+		nIdx, ok := w.tileIndex(a.X+off[0], a.Y+off[1])
+		if !ok || !w.agentPassable(nIdx) {
+			continue
+		}
+		nx, ny := nIdx%w.w, nIdx/w.w
+		half := a.Energy / 2
+		w.agents[i].Energy = half
+		w.addAgent(Agent{Kind: a.Kind, X: nx, Y: ny, Energy: half})
+		w.population.Births++
+		return
+	}
+}
+
+// hazardCentroid averages the positions of lava and burning tiles within
+// radius of (x, y).
+func (w *World) hazardCentroid(x, y, radius int) (float64, float64, bool) {
+	if radius <= 0 {
+		return 0, 0, false
+	}
+	var sumX, sumY float64
+	count := 0
+	minX, maxX := w.tileSpan(x-radius, x+radius, w.w)
+	minY, maxY := w.tileSpan(y-radius, y+radius, w.h)
+	for ny := minY; ny <= maxY; ny++ {
+		for nx := minX; nx <= maxX; nx++ {
+			idx, ok := w.tileIndex(nx, ny)
+			if !ok || !w.agentHazard(idx) {
+				continue
+			}
+			sumX += float64(nx)
+			sumY += float64(ny)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0, false
+	}
+	return w.wrapPosition(sumX/float64(count), w.w), w.wrapPosition(sumY/float64(count), w.h), true
+}
+
+func (w *World) nearestHerbivore(x, y, radius int) (int, int, bool) {
+	bestDist := -1
+	bestX, bestY := 0, 0
+	minX, maxX := w.tileSpan(x-radius, x+radius, w.w)
+	minY, maxY := w.tileSpan(y-radius, y+radius, w.h)
+	for ny := minY; ny <= maxY; ny++ {
+		for nx := minX; nx <= maxX; nx++ {
+			cell, ok := w.tileIndex(nx, ny)
+			if !ok {
+				continue
+			}
+			idx := int(w.agentCell[cell]) - 1
+			if idx < 0 || w.agents[idx].Kind != AgentHerbivore || w.agents[idx].dead {
+				continue
+			}
+			dx, dy := nx-x, ny-y
+			if d := dx*dx + dy*dy; bestDist < 0 || d < bestDist {
+				bestDist, bestX, bestY = d, cell%w.w, cell/w.w
+			}
+		}
+	}
+	return bestX, bestY, bestDist >= 0
+}
@@ -0,0 +1,135 @@
+package ecology
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHerbivoresGrazeAndStarve(t *testing.T) {
+	world := newTestWorld(t, 5, 1, 5)
+	world.cfg.Params.HerbivoreReproduceChance = 0
+	world.vegCurr[0] = VegetationShrub
+	world.addAgent(Agent{Kind: AgentHerbivore, X: 0, Y: 0, Energy: 0.5})
+	world.addAgent(Agent{Kind: AgentHerbivore, X: 4, Y: 0, Energy: world.cfg.Params.HerbivoreMetabolism / 2})
+
+	world.applyAgents()
+
+	if world.vegCurr[0] != VegetationGrass {
+		t.Fatalf("grazing should crop shrub to grass, got %v", world.vegCurr[0])
+	}
+	if got := world.PopulationSummary(); got.Herbivores != 1 || got.Starved != 1 {
+		t.Fatalf("expected one starved and one surviving herbivore, got %+v", got)
+	}
+	want := 0.5 - world.cfg.Params.HerbivoreMetabolism + world.cfg.Params.HerbivoreShrubGain
+	if got := world.Agents()[0].Energy; got < want-1e-9 || got > want+1e-9 {
+		t.Fatalf("expected energy %.3f after grazing, got %.3f", want, got)
+	}
+}
+
+func TestLavaAndFireKillAgents(t *testing.T) {
+	world := newTestWorld(t, 3, 1, 5)
+	world.groundCurr[0] = GroundLava
+	world.vegCurr[2] = VegetationGrass
+	world.burnTTL[2] = 2
+	world.addAgent(Agent{Kind: AgentHerbivore, X: 0, Y: 0, Energy: 1})
+	world.addAgent(Agent{Kind: AgentPredator, X: 2, Y: 0, Energy: 1})
+
+	world.applyAgents()
+
+	if got := world.PopulationSummary(); got.HazardDeaths != 2 || len(world.Agents()) != 0 {
+		t.Fatalf("expected lava and fire to kill both agents, got %+v", got)
+	}
+}
+
+func TestHerbivoreFleesFire(t *testing.T) {
+	world := newTestWorld(t, 7, 1, 5)
+	world.vegCurr[0] = VegetationGrass
+	world.burnTTL[0] = 3
+	world.vegCurr[2] = VegetationGrass
+	world.addAgent(Agent{Kind: AgentHerbivore, X: 2, Y: 0, Energy: 0.5})
+
+	world.applyAgents()
+
+	if x := world.Agents()[0].X; x != 3 {
+		t.Fatalf("herbivore should step away from the fire instead of grazing, got x=%d", x)
+	}
+	if world.vegCurr[2] != VegetationGrass {
+		t.Fatalf("fleeing herbivore should not graze")
+	}
+}
+
+func TestPredatorHuntsHerbivores(t *testing.T) {
+	world := newTestWorld(t, 9, 1, 5)
+	world.cfg.Params.PredatorHuntChance = 1
+	world.cfg.Params.HerbivoreReproduceChance = 0
+	world.cfg.Params.PredatorReproduceChance = 0
+	world.addAgent(Agent{Kind: AgentPredator, X: 0, Y: 0, Energy: 0.5})
+	world.addAgent(Agent{Kind: AgentHerbivore, X: 5, Y: 0, Energy: 5})
+
+	eaten := 0
+	for tick := 0; tick < 10 && eaten == 0; tick++ {
+		world.applyAgents()
+		eaten += world.PopulationSummary().Eaten
+	}
+
+	if got := world.PopulationSummary(); eaten != 1 || got.Herbivores != 0 || got.Predators != 1 {
+		t.Fatalf("predator should catch the herbivore, got %+v", got)
+	}
+	if world.Agents()[0].Energy <= 0.5 {
+		t.Fatalf("predator should gain energy from the kill, got %.3f", world.Agents()[0].Energy)
+	}
+}
+
+func TestAgentsReproduceBySplittingEnergy(t *testing.T) {
+	world := newTestWorld(t, 3, 3, 5)
+	world.cfg.Params.HerbivoreReproduceChance = 1
+	world.addAgent(Agent{Kind: AgentHerbivore, X: 1, Y: 1, Energy: 2})
+
+	world.applyAgents()
+
+	agents := world.Agents()
+	if len(agents) != 2 || world.PopulationSummary().Births != 1 {
+		t.Fatalf("expected one birth, got %d agents", len(agents))
+	}
+	if agents[0].Energy != agents[1].Energy {
+		t.Fatalf("parent and offspring should split energy, got %.3f and %.3f", agents[0].Energy, agents[1].Energy)
+	}
+	if agents[0].X == agents[1].X && agents[0].Y == agents[1].Y {
+		t.Fatalf("offspring must occupy its own tile")
+	}
+}
+
+func TestAgentsDeterministicAndRendered(t *testing.T) {
+	run := func() *World {
+		cfg := DefaultConfig()
+		cfg.Width = 64
+		cfg.Height = 64
+		cfg.Seed = 99
+		cfg.Params.HerbivoreDensity = 0.2
+		cfg.Params.PredatorDensity = 0.05
+		world := NewWithConfig(cfg)
+		world.Reset(0)
+		for i := 0; i < 40; i++ {
+			world.Step()
+		}
+		return world
+	}
+
+	a, b := run(), run()
+	if len(a.Agents()) == 0 {
+		t.Fatalf("expected agents to survive 40 ticks")
+	}
+	if !reflect.DeepEqual(a.Agents(), b.Agents()) {
+		t.Fatalf("agents diverged between runs with the same seed")
+	}
+
+	for _, agent := range a.Agents() {
+		cell := a.Cells()[agent.Y*a.w+agent.X]
+		if cell != encodeDisplayAgent(agent.Kind) {
+			t.Fatalf("display cell %#x does not encode %v", cell, agent.Kind)
+		}
+		if a.Palette()[cell] != toRGBA(agentColor(agent.Kind)) {
+			t.Fatalf("palette entry for %v should use the agent colour", agent.Kind)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package ecology
+
+import "math"
+
+// lavaCanEnter reports whether lava may flow onto ground. Flows run over
+// earlier basalt and every soil biome as readily as over dirt and rock.
+func lavaCanEnter(ground Ground) bool {
+	return ground == GroundRock || ground == GroundBasalt || soilGround(ground) || ground == GroundSnow
+}
+
+// solidifyLava turns units of lava column at idx into basalt. The units raise
+// the persistent terrain and the lava routing surface, so repeated flows pile
+// up into shields and cones that later flows must route around or over, and
+// they are recorded in the flow history.
+func (w *World) solidifyLava(idx, units int) {
+	if units <= 0 || idx < 0 || idx >= len(w.lavaElevation) {
+		return
+	}
+	raise := func(v int16) int16 {
+		return int16(min(int(v)+units, math.MaxInt16))
+	}
+	w.lavaElevation[idx] = raise(w.lavaElevation[idx])
+	if idx < len(w.terrainElevation) {
+		w.terrainElevation[idx] = raise(w.terrainElevation[idx])
+	}
+	if idx < len(w.basaltDepth) {
+		w.basaltDepth[idx] = raise(w.basaltDepth[idx])
+	}
+}
+
+// quenchLava freezes the whole lava column at idx into basalt at once, as
+// when a flow runs into open water.
+func (w *World) quenchLava(idx int) {
+	if idx < 0 || idx >= len(w.groundCurr) || w.groundCurr[idx] != GroundLava {
+		return
+	}
+	height := int(w.lavaHeight[idx])
+	w.setLavaCell(idx, 0, 0, -1, false)
+	w.groundCurr[idx] = GroundBasalt
+	w.solidifyLava(idx, max(height, 1))
+	if idx < len(w.lavaScar) {
+		w.lavaScar[idx] = 1
+	}
+}
@@ -0,0 +1,120 @@
+package ecology
+
+import "testing"
+
+func TestCooledLavaBuildsBasaltElevation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Width = 1
+	cfg.Height = 1
+	cfg.Params.GrassPatchCount = 0
+
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+	world.setLavaCell(0, 3, 1, -1, false)
+	world.lavaElevation[0] = 3
+	world.terrainElevation[0] = 3
+
+	for i := 0; i < 60 && world.groundCurr[0] == GroundLava; i++ {
+		world.applyLava()
+	}
+
+	if world.groundCurr[0] != GroundBasalt {
+		t.Fatalf("expected the flow to solidify into basalt, got %v", world.groundCurr[0])
+	}
+	if world.lavaElevation[0] != 6 || world.terrainElevation[0] != 6 {
+		t.Fatalf("expected the 3-unit column to raise elevation to 6, got lava %d terrain %d", world.lavaElevation[0], world.terrainElevation[0])
+	}
+	if history := world.Field("lava_history"); len(history) != 1 || history[0] != 3 {
+		t.Fatalf("expected the flow history to record 3 layers, got %v", history)
+	}
+}
+
+func TestLaterFlowsRouteAroundBasaltMounds(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Width = 3
+	cfg.Height = 3
+	cfg.Params.GrassPatchCount = 0
+	cfg.Params.RockChance = 0
+	cfg.Params.LavaAlignWeight = 0
+
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+	world.streams.lava.Seed(5)
+	for i := range world.groundCurr {
+		world.groundCurr[i] = GroundRock
+		world.lavaElevation[i] = 5
+	}
+	source := 1*3 + 0
+	world.lavaElevation[source] = 8
+	world.setLavaCell(source, 4, 1, 0, true)
+	world.lavaForce[source] = true
+
+	// An earlier flow left a basalt mound straight ahead of the source.
+	ahead := 1*3 + 1
+	world.groundCurr[ahead] = GroundBasalt
+	world.solidifyLava(ahead, 6)
+
+	world.applyLava()
+
+	if world.groundCurr[ahead] == GroundLava {
+		t.Fatalf("lava should route around the raised basalt mound")
+	}
+	if world.groundCurr[0*3+1] != GroundLava && world.groundCurr[2*3+1] != GroundLava {
+		t.Fatalf("expected lava to advance beside the mound")
+	}
+
+	// Basalt below the lava is no obstacle: later flows run over it.
+	cfg.Width = 2
+	cfg.Height = 1
+	over := NewWithConfig(cfg)
+	over.Reset(0)
+	over.groundCurr[1] = GroundBasalt
+	over.lavaElevation[0] = 8
+	over.lavaElevation[1] = 5
+	over.setLavaCell(0, 4, 1, 0, true)
+	over.lavaForce[0] = true
+	over.applyLava()
+	if over.groundCurr[1] != GroundLava {
+		t.Fatalf("expected lava to flow over lower basalt, got %v", over.groundCurr[1])
+	}
+}
+
+func TestGrassColonisesBasalt(t *testing.T) {
+	world := newTestWorld(t, 3, 3, 21, growthOff)
+	world.cfg.Params.GrassSpreadChance = 1
+	world.cfg.Params.GrassNeighborThreshold = 1
+	world.cfg.Params.BasaltGrassFactor = 1
+	world.cfg.Params.LavaRegrowthDelay = 1000
+	for i := range world.groundCurr {
+		world.groundCurr[i] = GroundBasalt
+		world.soilMoisture[i] = 1
+	}
+	world.vegCurr[4] = VegetationGrass
+
+	stepVegetation(world)
+
+	colonised := 0
+	for i, veg := range world.vegCurr {
+		if i != 4 && veg == VegetationGrass {
+			colonised++
+		}
+	}
+	if colonised == 0 {
+		t.Fatalf("expected grass to spread onto basalt")
+	}
+
+	barren := newTestWorld(t, 3, 3, 21, growthOff)
+	barren.cfg.Params.GrassSpreadChance = 1
+	barren.cfg.Params.GrassNeighborThreshold = 1
+	barren.cfg.Params.BasaltGrassFactor = 0
+	for i := range barren.groundCurr {
+		barren.groundCurr[i] = GroundBasalt
+	}
+	barren.vegCurr[4] = VegetationGrass
+	stepVegetation(barren)
+	for i, veg := range barren.vegCurr {
+		if i != 4 && veg != VegetationNone {
+			t.Fatalf("basalt grass factor 0 should keep fresh basalt bare, tile %d has %v", i, veg)
+		}
+	}
+}
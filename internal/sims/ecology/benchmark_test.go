@@ -0,0 +1,35 @@
+package ecology
+
+import "testing"
+
+// newBenchmarkWorld builds a size×size world and runs it past start-up so
+// the benchmark measures a settled landscape with fire, rain, and lava.
+func newBenchmarkWorld(b *testing.B, size int, terrain string) *World {
+	b.Helper()
+	cfg := DefaultConfig()
+	cfg.Width = size
+	cfg.Height = size
+	cfg.Terrain = terrain
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+	for tick := 0; tick < 50; tick++ {
+		world.Step()
+	}
+	return world
+}
+
+// benchmarkStep reports how many ticks per second Step runs at. Measured
+// rates and the 60 ticks/s target are in rules_specification.md §3.1.
+func benchmarkStep(b *testing.B, size int, terrain string) {
+	world := newBenchmarkWorld(b, size, terrain)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		world.Step()
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "ticks/s")
+}
+
+func BenchmarkStep256(b *testing.B)      { benchmarkStep(b, 256, TerrainFlat) }
+func BenchmarkStep512(b *testing.B)      { benchmarkStep(b, 512, TerrainFlat) }
+func BenchmarkStep512Noise(b *testing.B) { benchmarkStep(b, 512, TerrainNoise) }
@@ -0,0 +1,157 @@
+package ecology
+
+import "math"
+
+// biomeTraits scales succession and fire on a ground type. grass, shrub, and
+// tree multiply the chance of reaching that stage; a zero caps the tallest
+// cover the ground supports. moistureFloor is a water table that keeps the
+// soil from drying below it.
+type biomeTraits struct {
+	grass, shrub, tree float64
+	fire               float64
+	moistureFloor      float64
+}
+
+// groundTraits returns the succession and fire rules for ground. Grass only
+// spreads on soil; basalt's grass factor comes from BasaltGrassFactor.
+func groundTraits(ground Ground) biomeTraits {
+	switch ground {
+	case GroundDirt:
+		return biomeTraits{grass: 1, shrub: 1, tree: 1, fire: 1}
+	case GroundSand:
+		// Sparse, drought-hardy scrub with little fuel to carry fire.
+		return biomeTraits{grass: 0.3, shrub: 0.4, fire: 0.6}
+	case GroundSnow:
+		return biomeTraits{}
+	case GroundWetland:
+		// Lush and waterlogged: fast growth, few trees, hard to burn.
+		return biomeTraits{grass: 1.5, shrub: 1.2, tree: 0.4, fire: 0.3, moistureFloor: 0.6}
+	case GroundTundra:
+		// A short growing season keeps cover to grass and dwarf shrubs.
+		return biomeTraits{grass: 0.6, shrub: 0.3, fire: 0.5}
+	default:
+		return biomeTraits{shrub: 1, tree: 1, fire: 1}
+	}
+}
+
+// soilGround reports whether ground is soil that plants can seed into and
+// herbivores can graze.
+func soilGround(ground Ground) bool {
+	switch ground {
+	case GroundDirt, GroundSand, GroundWetland, GroundTundra:
+		return true
+	default:
+		return false
+	}
+}
+
+// biomeGrowth is the multiplier on the chance of growing stage on ground.
+func (w *World) biomeGrowth(ground Ground, stage Vegetation) float64 {
+	traits := groundTraits(ground)
+	switch stage {
+	case VegetationGrass:
+		if ground == GroundBasalt {
+			// Grass finds a footing in cracks in the basalt.
+			return w.cfg.Params.BasaltGrassFactor
+		}
+		return traits.grass
+	case VegetationShrub:
+		return traits.shrub
+	case VegetationTree:
+		return traits.tree
+	default:
+		return 1
+	}
+}
+
+// maxVegetation is the tallest cover ground supports.
+func maxVegetation(ground Ground) Vegetation {
+	traits := groundTraits(ground)
+	switch {
+	case traits.grass <= 0:
+		return VegetationNone
+	case traits.shrub <= 0:
+		return VegetationGrass
+	case traits.tree <= 0:
+		return VegetationShrub
+	default:
+		return VegetationTree
+	}
+}
+
+// landGround is the ground a tile returns to when water drains or rock and
+// basalt break down: its climate zone, or dirt with biomes off.
+func (w *World) landGround(idx int) Ground {
+	if idx < len(w.climateZone) {
+		return w.climateZone[idx]
+	}
+	return GroundDirt
+}
+
+// assignClimateZones classifies every tile by temperature and moisture when
+// Params.Biomes is on. Temperature falls toward the poles with
+// BiomeLatitudeCooling and with altitude with BiomeLapseRate. Moisture is the
+// seeded soil moisture roughened by low-frequency noise. Cold tiles turn to
+// snow and tundra, hot dry tiles to sand, and wet tiles to wetland. With
+// repaint set, dirt (and rock for snow) is replaced by the zone's ground and
+// vegetation the new ground cannot carry is cut back.
+func (w *World) assignClimateZones(seed int64, repaint bool) {
+	for i := range w.climateZone {
+		w.climateZone[i] = GroundDirt
+	}
+	params := w.cfg.Params
+	total := w.w * w.h
+	if !params.Biomes || total == 0 || len(w.climateZone) != total {
+		return
+	}
+
+	scale := params.TerrainScale * 2
+	noise := make([]float64, total)
+	for y := 0; y < w.h; y++ {
+		for x := 0; x < w.w; x++ {
+			noise[y*w.w+x] = w.periodicNoise(float64(x), float64(y), func(x, y float64) float64 {
+				return fbmNoise2D(x*scale-23.7, y*scale+71.3, 3, 0.5, 2, seed+307)
+			})
+		}
+	}
+	normalizeField(noise)
+
+	relief := math.Max(float64(params.TerrainRelief), 1)
+	for y := 0; y < w.h; y++ {
+		latitude := math.Abs(2*(float64(y)+0.5)/float64(w.h) - 1)
+		for x := 0; x < w.w; x++ {
+			idx := y*w.w + x
+			altitude := clamp01(float64(w.lavaElevation[idx]) / relief)
+			temperature := 1 - params.BiomeLatitudeCooling*latitude - params.BiomeLapseRate*altitude
+			moisture := clamp01(float64(w.soilMoisture[idx]) + params.BiomeMoistureNoise*(2*noise[idx]-1))
+
+			zone := GroundDirt
+			switch {
+			case temperature < params.BiomeSnowLine:
+				zone = GroundSnow
+			case temperature < params.BiomeTundraLine:
+				zone = GroundTundra
+			case moisture >= params.BiomeWetMoisture:
+				zone = GroundWetland
+			case temperature >= params.BiomeDesertLine && moisture <= params.BiomeDryMoisture:
+				zone = GroundSand
+			}
+			w.climateZone[idx] = zone
+
+			if !repaint || zone == GroundDirt {
+				continue
+			}
+			ground := w.groundCurr[idx]
+			if ground != GroundDirt && !(zone == GroundSnow && ground == GroundRock) {
+				continue
+			}
+			w.groundCurr[idx] = zone
+			if zone == GroundSand || zone == GroundWetland {
+				w.soilMoisture[idx] = float32(moisture)
+			}
+			if limit := maxVegetation(zone); w.vegCurr[idx] > limit {
+				w.vegCurr[idx] = limit
+			}
+		}
+	}
+}
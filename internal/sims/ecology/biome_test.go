@@ -0,0 +1,139 @@
+package ecology
+
+import "testing"
+
+func TestClimateZonesFollowLatitudeAltitudeAndMoisture(t *testing.T) {
+	world := newTestWorld(t, 4, 40, 17, func(cfg *Config) {
+		cfg.Params.Biomes = true
+		cfg.Params.BiomeMoistureNoise = 0
+	})
+	equator := 20 * world.w
+	if world.groundCurr[0] != GroundSnow {
+		t.Fatalf("expected a polar snowfield, got %v", world.groundCurr[0])
+	}
+	tundra := false
+	for y := 0; y < 20; y++ {
+		tundra = tundra || world.groundCurr[y*world.w] == GroundTundra
+	}
+	if !tundra {
+		t.Fatalf("expected tundra between the pole and the equator")
+	}
+	if world.groundCurr[equator] != GroundDirt {
+		t.Fatalf("expected temperate dirt at the equator, got %v", world.groundCurr[equator])
+	}
+
+	// A peak at the equator is cold; wet and dry lowlands beside it.
+	for i := range world.groundCurr {
+		world.groundCurr[i] = GroundDirt
+	}
+	world.cfg.Params.BiomeLapseRate = 1
+	world.lavaElevation[equator] = int16(world.cfg.Params.TerrainRelief)
+	world.soilMoisture[equator+1] = 0.9
+	world.soilMoisture[equator+2] = 0.1
+	world.assignClimateZones(0, true)
+	if got := world.groundCurr[equator]; got != GroundTundra && got != GroundSnow {
+		t.Fatalf("expected a cold biome on the equatorial peak, got %v", got)
+	}
+	if got := world.groundCurr[equator+1]; got != GroundWetland {
+		t.Fatalf("expected wet lowland to become wetland, got %v", got)
+	}
+	if got := world.groundCurr[equator+2]; got != GroundSand {
+		t.Fatalf("expected hot dry lowland to become sand, got %v", got)
+	}
+
+	plain := newTestWorld(t, 4, 40, 21, growthOff)
+	for i, zone := range plain.climateZone {
+		if zone != GroundDirt || plain.groundCurr[i] != GroundDirt {
+			t.Fatalf("biomes off should leave every tile dirt, tile %d is %v", i, plain.groundCurr[i])
+		}
+	}
+}
+
+func TestBiomesShapeSuccessionAndFire(t *testing.T) {
+	world := newTestWorld(t, 3, 1, 21, growthOff)
+	world.cfg.Params.TreeGrowthChance = 1
+	world.cfg.Params.TreeNeighborThreshold = 0
+	world.cfg.Params.GrassSpreadChance = 1
+	world.cfg.Params.GrassNeighborThreshold = 0
+	world.groundCurr[0] = GroundTundra
+	world.groundCurr[1] = GroundDirt
+	world.groundCurr[2] = GroundSnow
+	world.vegCurr[0] = VegetationShrub
+	world.vegCurr[1] = VegetationShrub
+	for i := range world.soilMoisture {
+		world.soilMoisture[i] = 1
+	}
+
+	stepVegetation(world)
+
+	if world.vegCurr[0] != VegetationShrub {
+		t.Fatalf("tundra should cap cover at dwarf shrubs, got %v", world.vegCurr[0])
+	}
+	if world.vegCurr[1] != VegetationTree {
+		t.Fatalf("dirt should still grow trees, got %v", world.vegCurr[1])
+	}
+	if world.vegCurr[2] != VegetationNone {
+		t.Fatalf("snow should stay bare, got %v", world.vegCurr[2])
+	}
+
+	world.groundCurr[0] = GroundWetland
+	world.groundCurr[2] = GroundSand
+	for i := range world.soilMoisture {
+		world.soilMoisture[i] = 0.5
+	}
+	wetland, dirt, sand := world.soilFireFactor(0), world.soilFireFactor(1), world.soilFireFactor(2)
+	if !(wetland < sand && sand < dirt) {
+		t.Fatalf("expected wetland < sand < dirt fire susceptibility, got %.2f %.2f %.2f", wetland, sand, dirt)
+	}
+
+	world.cfg.Params.SoilRainGain = 0
+	world.soilMoisture[0] = 0
+	world.updateSoilMoisture()
+	if world.soilMoisture[0] < float32(groundTraits(GroundWetland).moistureFloor) {
+		t.Fatalf("wetland soil should not dry below its water table, got %.2f", world.soilMoisture[0])
+	}
+}
+
+func TestDrainedLakeReturnsToClimateZone(t *testing.T) {
+	world := newTestWorld(t, 3, 1, 3, stillWater)
+	world.climateZone[1] = GroundWetland
+	world.groundCurr[1] = GroundWater
+
+	world.applyHydrology()
+
+	if world.groundCurr[1] != GroundWetland {
+		t.Fatalf("a drained lake should return to its climate zone, got %v", world.groundCurr[1])
+	}
+	if world.groundCurr[0] != GroundDirt {
+		t.Fatalf("tiles outside any zone should stay dirt, got %v", world.groundCurr[0])
+	}
+}
+
+func TestDisplayEncodesEveryGroundAndStage(t *testing.T) {
+	palette := ecologyPalette
+	seen := make(map[uint8]string)
+	record := func(value uint8, name string) {
+		t.Helper()
+		if prev, ok := seen[value]; ok {
+			t.Fatalf("%s and %s share display value %#x", prev, name, value)
+		}
+		seen[value] = name
+	}
+	for ground := GroundDirt; ground <= GroundTundra; ground++ {
+		for veg := VegetationNone; veg <= VegetationTree; veg++ {
+			value := encodeDisplayValue(ground, veg, false)
+			record(value, ground.String()+"/"+veg.String())
+			if want := toRGBA(paletteColorFor(ground, veg, false)); palette[value] != want {
+				t.Fatalf("palette entry for %v/%v is %v, want %v", ground, veg, palette[value], want)
+			}
+		}
+	}
+	record(encodeDisplayValue(GroundSand, VegetationGrass, true), "burning")
+	record(displayFlash, "flash")
+	record(encodeDisplayAgent(AgentHerbivore), "herbivore")
+	record(encodeDisplayAgent(AgentPredator), "predator")
+
+	if palette[encodeDisplayValue(GroundSand, VegetationNone, false)] == palette[encodeDisplayValue(GroundDirt, VegetationNone, false)] {
+		t.Fatalf("sand should render differently from dirt")
+	}
+}
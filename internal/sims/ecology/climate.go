@@ -0,0 +1,125 @@
+package ecology
+
+import (
+	"fmt"
+	"math"
+
+	"mad-ca/internal/core"
+)
+
+// Season names the quarter of the climate year.
+type Season uint8
+
+const (
+	SeasonSpring Season = iota
+	SeasonSummer
+	SeasonAutumn
+	SeasonWinter
+)
+
+func (s Season) String() string {
+	switch s {
+	case SeasonSpring:
+		return "spring"
+	case SeasonSummer:
+		return "summer"
+	case SeasonAutumn:
+		return "autumn"
+	case SeasonWinter:
+		return "winter"
+	default:
+		return "unknown"
+	}
+}
+
+// ClimateState is the climate clock at the current tick together with the
+// multipliers it applies to the environment. Every multiplier is 1 when the
+// corresponding cycle is disabled.
+type ClimateState struct {
+	Tick int
+	Year int
+
+	Season Season
+	// SeasonProgress runs from 0 to 1 through the current season.
+	SeasonProgress float64
+	// Daylight is 0 at midnight and 1 at noon.
+	Daylight float64
+
+	Rain        float64
+	Wind        float64
+	Growth      float64
+	Fire        float64
+	Evaporation float64
+}
+
+// Climate reports the current climate clock and multipliers.
+func (w *World) Climate() ClimateState { return w.climate }
+
+// updateClimate derives the climate state from climateTick. The year is
+// monsoon-style: a sine over four seasons of SeasonLength ticks brings the
+// wettest, greenest and calmest weather at midsummer and the driest, windiest
+// fire season at midwinter. Tick 0 falls on the spring equinox and at dawn,
+// so every multiplier starts at exactly 1. A DayLength-tick day/night cycle
+// further scales growth and evaporation with daylight.
+func (w *World) updateClimate() {
+	params := w.cfg.Params
+	state := ClimateState{
+		Tick:        w.climateTick,
+		Daylight:    0.5,
+		Rain:        1,
+		Wind:        1,
+		Growth:      1,
+		Fire:        1,
+		Evaporation: 1,
+	}
+
+	if params.SeasonLength > 0 {
+		year := 4 * params.SeasonLength
+		state.Year = w.climateTick / year
+		phase := float64(w.climateTick%year) / float64(year)
+		// Seasons are centred on the equinoxes and solstices.
+		quarter := math.Mod(phase+0.125, 1) * 4
+		state.Season = Season(int(quarter) % 4)
+		state.SeasonProgress = quarter - math.Floor(quarter)
+
+		wet := math.Sin(2 * math.Pi * phase)
+		state.Rain = math.Max(0, 1+params.ClimateRainAmplitude*wet)
+		state.Growth = math.Max(0, 1+params.ClimateGrowthAmplitude*wet)
+		state.Fire = math.Max(0, 1-params.ClimateFireAmplitude*wet)
+		state.Wind = math.Max(0, 1-params.ClimateWindAmplitude*wet)
+	}
+
+	if params.DayLength > 0 {
+		sun := math.Sin(2 * math.Pi * float64(w.climateTick%params.DayLength) / float64(params.DayLength))
+		state.Daylight = 0.5 + 0.5*sun
+		diurnal := math.Max(0, 1+params.ClimateDayAmplitude*sun)
+		state.Growth *= diurnal
+		state.Evaporation = diurnal
+	}
+
+	w.climate = state
+}
+
+// Status reports the climate clock for the HUD header.
+func (w *World) Status() []core.NamedValue {
+	return []core.NamedValue{
+		{Name: "Season", Value: w.climateSummary()},
+		{Name: "Climate", Value: fmt.Sprintf("rain x%.2f  fire x%.2f", w.climate.Rain, w.climate.Fire)},
+	}
+}
+
+func (w *World) climateSummary() string {
+	c := w.climate
+	summary := "off"
+	if w.cfg.Params.SeasonLength > 0 {
+		summary = fmt.Sprintf("%s %d%% (year %d)", c.Season, int(c.SeasonProgress*100), c.Year+1)
+	}
+	if w.cfg.Params.DayLength > 0 {
+		if c.Daylight >= 0.5 {
+			summary += ", day"
+		} else {
+			summary += ", night"
+		}
+	}
+	return summary
+}
@@ -0,0 +1,146 @@
+package ecology
+
+import (
+	"strings"
+	"testing"
+)
+
+// shortClimate shortens the year and day so tests can step through them.
+func shortClimate(cfg *Config) {
+	cfg.Params.SeasonLength = 100
+	cfg.Params.DayLength = 10
+}
+
+func advanceClimate(world *World, ticks int) {
+	for i := 0; i < ticks; i++ {
+		world.climateTick++
+		world.updateClimate()
+	}
+}
+
+func TestClimateStartsNeutral(t *testing.T) {
+	world := newTestWorld(t, 8, 8, 5, shortClimate)
+	c := world.Climate()
+	if c.Rain != 1 || c.Wind != 1 || c.Growth != 1 || c.Fire != 1 || c.Evaporation != 1 {
+		t.Fatalf("tick 0 should leave every multiplier at 1, got %+v", c)
+	}
+	if c.Season != SeasonSpring {
+		t.Fatalf("the year should open in spring, got %v", c.Season)
+	}
+}
+
+func TestClimateCyclesThroughSeasons(t *testing.T) {
+	world := newTestWorld(t, 8, 8, 5, shortClimate)
+	world.cfg.Params.DayLength = 0
+	world.updateClimate()
+
+	var order []Season
+	for tick := 0; tick < 400; tick++ {
+		if s := world.Climate().Season; len(order) == 0 || order[len(order)-1] != s {
+			order = append(order, s)
+		}
+		advanceClimate(world, 1)
+	}
+	want := []Season{SeasonSpring, SeasonSummer, SeasonAutumn, SeasonWinter, SeasonSpring}
+	if len(order) != len(want) {
+		t.Fatalf("expected seasons %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected seasons %v, got %v", want, order)
+		}
+	}
+	if c := world.Climate(); c.Year != 1 || c.Tick != 400 {
+		t.Fatalf("expected the second year after 400 ticks, got year %d tick %d", c.Year, c.Tick)
+	}
+}
+
+func TestClimateSummerIsWetAndWinterIsFireSeason(t *testing.T) {
+	world := newTestWorld(t, 8, 8, 5, shortClimate)
+	world.cfg.Params.DayLength = 0
+
+	advanceClimate(world, 100) // midsummer
+	summer := world.Climate()
+	if summer.Season != SeasonSummer || summer.Rain <= 1 || summer.Growth <= 1 || summer.Fire >= 1 {
+		t.Fatalf("midsummer should be wet and green, got %+v", summer)
+	}
+	advanceClimate(world, 200) // midwinter
+	winter := world.Climate()
+	if winter.Season != SeasonWinter || winter.Fire <= 1 || winter.Rain >= 1 || winter.Wind <= 1 {
+		t.Fatalf("midwinter should be the dry, windy fire season, got %+v", winter)
+	}
+
+	idx := 0
+	world.soilMoisture[idx] = 0.3
+	dry := world.soilFireFactor(idx)
+	world.climateTick = 100
+	world.updateClimate()
+	if wet := world.soilFireFactor(idx); wet >= dry {
+		t.Fatalf("fire susceptibility should peak in the dry season: summer %.3f winter %.3f", wet, dry)
+	}
+}
+
+func TestClimateDayNightScalesGrowth(t *testing.T) {
+	world := newTestWorld(t, 8, 8, 5, shortClimate)
+	world.cfg.Params.SeasonLength = 0
+
+	advanceClimate(world, 2) // morning, near noon at tick 2.5
+	day := world.Climate()
+	advanceClimate(world, 5) // night
+	night := world.Climate()
+	if day.Daylight <= 0.5 || night.Daylight >= 0.5 {
+		t.Fatalf("expected day then night, got daylight %.2f and %.2f", day.Daylight, night.Daylight)
+	}
+	if day.Growth <= night.Growth || day.Evaporation <= night.Evaporation {
+		t.Fatalf("daylight should boost growth and evaporation: day %+v night %+v", day, night)
+	}
+	if night.Rain != 1 || night.Fire != 1 {
+		t.Fatalf("disabled seasons should leave rain and fire neutral, got %+v", night)
+	}
+}
+
+func TestClimateDisabledCyclesStayNeutral(t *testing.T) {
+	world := newTestWorld(t, 8, 8, 5)
+	advanceClimate(world, 137)
+	c := world.Climate()
+	if c.Rain != 1 || c.Wind != 1 || c.Growth != 1 || c.Fire != 1 || c.Evaporation != 1 {
+		t.Fatalf("disabled climate should be neutral, got %+v", c)
+	}
+	if world.climateSummary() != "off" {
+		t.Fatalf("expected summary off, got %q", world.climateSummary())
+	}
+}
+
+func TestClimateIsDeterministicAndResets(t *testing.T) {
+	a := newTestWorld(t, 8, 8, 5, shortClimate)
+	b := newTestWorld(t, 8, 8, 5, shortClimate)
+	for i := 0; i < 150; i++ {
+		a.Step()
+		b.Step()
+	}
+	if a.Climate() != b.Climate() {
+		t.Fatalf("climate diverged: %+v vs %+v", a.Climate(), b.Climate())
+	}
+	if a.Climate().Tick != 150 {
+		t.Fatalf("expected the clock to advance once per step, got %d", a.Climate().Tick)
+	}
+	a.Reset(0)
+	if c := a.Climate(); c.Tick != 0 || c.Rain != 1 {
+		t.Fatalf("reset should rewind the climate clock, got %+v", c)
+	}
+}
+
+func TestParametersExposeCurrentSeason(t *testing.T) {
+	world := newTestWorld(t, 8, 8, 5, shortClimate)
+	advanceClimate(world, 100)
+	for _, group := range world.Parameters().Groups {
+		if group.Name != "Climate" {
+			continue
+		}
+		if !strings.Contains(group.Summary, "summer") {
+			t.Fatalf("climate summary should name the season, got %q", group.Summary)
+		}
+		return
+	}
+	t.Fatalf("climate parameter group missing")
+}
@@ -1,69 +1,224 @@
 package ecology
 
-import "strconv"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
 
-// Params holds tunable thresholds and probabilities for the ecology sim.
+	"mad-ca/internal/core"
+)
+
+// Params holds tunable thresholds and probabilities for the ecology sim. The
+// field tags declare each parameter's config key, label, snapshot group,
+// bounds, and HUD exposure once for paramSchema; see core.ParamSchema.
 type Params struct {
-	RockChance          float64
-	GrassPatchCount     int
-	GrassPatchRadiusMin int
-	GrassPatchRadiusMax int
-	GrassPatchDensity   float64
-
-	LavaSpreadChance    float64
-	LavaSpreadMaskFloor float64
-	LavaFluxRef         float64
-	LavaCoolBase        float64
-	LavaCoolRain        float64
-	LavaCoolEdge        float64
-	LavaCoolThick       float64
-	LavaCoolFlux        float64
-	LavaPhaseThreshold  float64
-	LavaPhaseHysteresis float64
-	LavaReservoirMin    int
-	LavaReservoirMax    int
-	LavaReservoirGain   float64
-	LavaReservoirHead   float64
-	BurnTTL             int
-
-	FireSpreadChance         float64
-	FireLavaIgniteChance     float64
-	FireRainSpreadDampen     float64
-	FireRainExtinguishChance float64
-
-	RainMaxRegions  int
-	RainSpawnChance float64
-	RainRadiusMin   int
-	RainRadiusMax   int
-	RainTTLMin      int
-	RainTTLMax      int
-	RainStrengthMin float64
-	RainStrengthMax float64
-
-	WindNoiseScale    float64
-	WindSpeedScale    float64
-	WindTemporalScale float64
-
-	GrassNeighborThreshold int
-	GrassSpreadChance      float64
-	ShrubNeighborThreshold int
-	ShrubGrowthChance      float64
-	TreeNeighborThreshold  int
-	TreeGrowthChance       float64
-
-	VolcanoProtoMaxRegions        int
-	VolcanoProtoSpawnChance       float64
-	VolcanoProtoTectonicThreshold float64
-	VolcanoProtoRadiusMin         int
-	VolcanoProtoRadiusMax         int
-	VolcanoProtoTTLMin            int
-	VolcanoProtoTTLMax            int
-	VolcanoProtoStrengthMin       float64
-	VolcanoProtoStrengthMax       float64
-	VolcanoUpliftChanceBase       float64
-	VolcanoEruptionChanceBase     float64
+	// Subsystem switches. A disabled subsystem's phases are skipped by Step:
+	// Rain clears rain regions, Wind stills the air that drifts rain, fire,
+	// and seeds, Volcanoes stops proto-volcanoes, uplift, and eruptions, Lava
+	// freezes lava in place, Fire puts out fires and stops lightning,
+	// Succession freezes vegetation, Agents freezes herbivores and predators
+	// where they stand, and Soil holds soil moisture at its current values.
+	// Hydrology and Erosion switch surface water and erosion the same way.
+	Rain       bool `param:"rain" label:"Rain" group:"Subsystems" opts:"hud"`
+	Wind       bool `param:"wind" label:"Wind" opts:"hud"`
+	Volcanoes  bool `param:"volcanoes" label:"Volcanoes & uplift" opts:"hud"`
+	Lava       bool `param:"lava" label:"Lava flow" opts:"hud"`
+	Fire       bool `param:"fire" label:"Fire & lightning" opts:"hud"`
+	Succession bool `param:"succession" label:"Vegetation succession" opts:"hud"`
+	Agents     bool `param:"agents" label:"Herbivores & predators" opts:"hud"`
+	Soil       bool `param:"soil" label:"Soil moisture" opts:"hud"`
+
+	RockChance          float64 `param:"rock_chance" label:"Rock chance" group:"Terrain Seeding" opts:"percent"`
+	GrassPatchCount     int     `param:"grass_patch_count" label:"Grass patch count" min:"0"`
+	GrassPatchRadiusMin int     `param:"grass_patch_radius_min" label:"Grass patch radius min" min:"0"`
+	GrassPatchRadiusMax int     `param:"grass_patch_radius_max" label:"Grass patch radius max" min:"0" floor:"grass_patch_radius_min"`
+	GrassPatchDensity   float64 `param:"grass_patch_density" label:"Grass patch density" min:"0" max:"1" opts:"clamp"`
+
+	TerrainScale         float64 `param:"terrain_scale" label:"Terrain noise scale" group:"Terrain" min:"0.001"`
+	TerrainRelief        int     `param:"terrain_relief" label:"Terrain relief" min:"0" max:"4096" opts:"clamp"`
+	TerrainWaterLevel    float64 `param:"terrain_water_level" label:"Terrain water level" min:"0" max:"1" opts:"clamp"`
+	TerrainMountainLevel float64 `param:"terrain_mountain_level" label:"Terrain mountain level" min:"0" max:"1" opts:"clamp"`
+	TerrainPlateCount    int     `param:"terrain_plate_count" label:"Tectonic plates" min:"2"`
+
+	Biomes               bool    `param:"biomes" label:"Climate biomes" group:"Biomes"`
+	BiomeLatitudeCooling float64 `param:"biome_latitude_cooling" label:"Polar cooling" min:"0" max:"1" opts:"clamp"`
+	BiomeLapseRate       float64 `param:"biome_lapse_rate" label:"Altitude cooling" min:"0" max:"1" opts:"clamp"`
+	BiomeSnowLine        float64 `param:"biome_snow_line" label:"Snow line temperature" min:"0" max:"1" opts:"clamp"`
+	BiomeTundraLine      float64 `param:"biome_tundra_line" label:"Tundra temperature" min:"0" max:"1" floor:"biome_snow_line" opts:"clamp"`
+	BiomeDesertLine      float64 `param:"biome_desert_line" label:"Desert temperature" min:"0" max:"1" opts:"clamp"`
+	BiomeDryMoisture     float64 `param:"biome_dry_moisture" label:"Desert moisture" min:"0" max:"1" opts:"clamp"`
+	BiomeWetMoisture     float64 `param:"biome_wet_moisture" label:"Wetland moisture" min:"0" max:"1" floor:"biome_dry_moisture" opts:"clamp"`
+	BiomeMoistureNoise   float64 `param:"biome_moisture_noise" label:"Moisture patchiness" min:"0" max:"1" opts:"clamp"`
+
+	LavaSpreadChance    float64 `param:"lava_spread_chance" label:"Lava spread chance" group:"Lava" opts:"percent,hud"`
+	LavaSpreadMaskFloor float64 `param:"lava_spread_mask_floor" label:"Lava spread mask floor" min:"0" max:"1" opts:"clamp"`
+	LavaFluxRef         float64 `param:"lava_flux_ref" label:"Lava flux reference" min:"0.1" max:"8" opts:"clamp,hud"`
+	LavaCoolBase        float64 `param:"lava_cool_base" label:"Lava base cooling" min:"0" max:"0.1" opts:"clamp,hud"`
+	LavaCoolRain        float64 `param:"lava_cool_rain" label:"Lava rain cooling" min:"0"`
+	LavaCoolEdge        float64 `param:"lava_cool_edge" label:"Lava edge cooling" min:"0"`
+	LavaCoolThick       float64 `param:"lava_cool_thick" label:"Lava thickness cooling" min:"0"`
+	LavaCoolFlux        float64 `param:"lava_cool_flux" label:"Lava flux cooling" min:"0" max:"0.1" opts:"clamp,hud"`
+	LavaPhaseThreshold  float64 `param:"lava_phase_threshold" label:"Lava crust threshold" min:"0" max:"1" opts:"clamp"`
+	LavaPhaseHysteresis float64 `param:"lava_phase_hysteresis" label:"Lava thermal hysteresis" min:"0"`
+	LavaReservoirMin    int     `param:"lava_reservoir_min" label:"Lava reservoir min" min:"0"`
+	LavaReservoirMax    int     `param:"lava_reservoir_max" label:"Lava reservoir max" min:"0" floor:"lava_reservoir_min"`
+	LavaReservoirGain   float64 `param:"lava_reservoir_gain" label:"Lava reservoir gain" min:"0"`
+	LavaReservoirHead   float64 `param:"lava_reservoir_head" label:"Lava reservoir head" min:"0" max:"6" opts:"clamp,hud"`
+
+	// Lava flow shape. Low LavaSpeedAlpha and LavaMaxHeight give thin, fast
+	// pahoehoe sheets; high values give thick, slow a'a tongues that stall
+	// and pile up.
+	LavaMaxHeight          int     `param:"lava_max_height" label:"Lava max height" group:"Lava Flow" min:"1" max:"255" step:"1" opts:"clamp,hud"`
+	LavaOverflowHeight     int     `param:"lava_overflow_height" label:"Lava overflow height" min:"1" max:"255" opts:"clamp"`
+	LavaBaseSpeed          float64 `param:"lava_base_speed" label:"Lava base speed" min:"0" max:"4" opts:"clamp,hud"`
+	LavaSpeedAlpha         float64 `param:"lava_speed_alpha" label:"Lava viscosity" min:"0" max:"8" opts:"clamp,hud"`
+	LavaFlowThreshold      float64 `param:"lava_flow_threshold" label:"Lava flow threshold" min:"-8" max:"8" opts:"clamp,hud"`
+	LavaSplitChance        float64 `param:"lava_split_chance" label:"Lava split chance" opts:"percent,hud"`
+	LavaSplitMinHeight     int     `param:"lava_split_min_height" label:"Lava split min height" min:"1" max:"255" opts:"clamp"`
+	LavaSplitThresholdDrop float64 `param:"lava_split_threshold_drop" label:"Lava split threshold drop" min:"0" max:"8" opts:"clamp"`
+	LavaSlopeWeight        float64 `param:"lava_slope_weight" label:"Lava slope weight" min:"0" max:"8" opts:"clamp"`
+	LavaAlignWeight        float64 `param:"lava_align_weight" label:"Lava momentum weight" min:"0" max:"8" opts:"clamp"`
+	LavaChannelWeight      float64 `param:"lava_channel_weight" label:"Lava channel weight" min:"0" max:"8" opts:"clamp"`
+	LavaRainWeight         float64 `param:"lava_rain_weight" label:"Lava rain weight" min:"0" max:"8" opts:"clamp"`
+	LavaWallWeight         float64 `param:"lava_wall_weight" label:"Lava wall weight" min:"0" max:"8" opts:"clamp"`
+	LavaChannelGrow        float64 `param:"lava_channel_grow" label:"Lava channel growth" min:"0" max:"1" opts:"clamp"`
+	LavaChannelDecay       float64 `param:"lava_channel_decay" label:"Lava channel decay" min:"0" max:"1" opts:"clamp"`
+	LavaTipTemperatureMin  float64 `param:"lava_tip_temperature_min" label:"Lava tip min temperature" min:"0" max:"1" opts:"clamp"`
+	LavaReheatCap          float64 `param:"lava_reheat_cap" label:"Lava reheat cap" min:"0" max:"1" opts:"clamp"`
+
+	BurnTTL                  int     `param:"burn_ttl" label:"Burn TTL" group:"Fire" min:"1" step:"1" opts:"hud"`
+	FireSpreadChance         float64 `param:"fire_spread_chance" label:"Fire spread chance" opts:"percent,hud"`
+	FireLavaIgniteChance     float64 `param:"fire_lava_ignite_chance" label:"Fire lava ignite chance" opts:"percent"`
+	FireRainSpreadDampen     float64 `param:"fire_rain_spread_dampen" label:"Fire rain spread dampen" min:"0" max:"1" opts:"clamp,hud"`
+	FireRainExtinguishChance float64 `param:"fire_rain_extinguish_chance" label:"Fire rain extinguish chance" opts:"percent"`
+	FireWindBias             float64 `param:"fire_wind_bias" label:"Fire wind bias" min:"0" max:"4" opts:"clamp,hud"`
+	FireEmberChance          float64 `param:"fire_ember_chance" label:"Fire ember chance" opts:"percent,hud"`
+	FireEmberDistance        int     `param:"fire_ember_distance" label:"Fire ember distance" min:"2" step:"1" opts:"hud"`
+	FireSoilDampen           float64 `param:"fire_soil_dampen" label:"Fire soil moisture dampen" min:"0"`
+
+	LightningChance       float64 `param:"lightning_chance" label:"Lightning chance" group:"Lightning" opts:"percent,hud"`
+	LightningSquallBoost  float64 `param:"lightning_squall_boost" label:"Lightning squall boost" min:"0"`
+	LightningEdgeBias     float64 `param:"lightning_edge_bias" label:"Lightning edge bias" min:"0" max:"1" opts:"clamp"`
+	LightningDryChance    float64 `param:"lightning_dry_chance" label:"Dry lightning chance" opts:"percent"`
+	LightningIgniteChance float64 `param:"lightning_ignite_chance" label:"Lightning ignite chance" opts:"percent"`
+	LightningFlashTicks   int     `param:"lightning_flash_ticks" label:"Lightning flash ticks" min:"0" max:"255" opts:"clamp"`
+
+	SoilMoistureInitial float64 `param:"soil_moisture_initial" label:"Soil moisture initial" group:"Soil" min:"0" max:"1" opts:"clamp"`
+	SoilRainGain        float64 `param:"soil_rain_gain" label:"Soil rain gain" min:"0" max:"1" opts:"clamp,hud"`
+	SoilEvaporation     float64 `param:"soil_evaporation" label:"Soil evaporation" min:"0" max:"0.1" opts:"clamp,hud"`
+	SoilHeatEvaporation float64 `param:"soil_heat_evaporation" label:"Soil heat evaporation" min:"0"`
+	SoilGrowthDry       float64 `param:"soil_growth_dry" label:"Soil growth factor (dry)" min:"0"`
+	SoilGrowthWet       float64 `param:"soil_growth_wet" label:"Soil growth factor (wet)" min:"0"`
+
+	Hydrology         bool    `param:"hydrology" label:"Surface water" group:"Water"`
+	WaterRainGain     float64 `param:"water_rain_gain" label:"Water rain gain" min:"0"`
+	WaterEvaporation  float64 `param:"water_evaporation" label:"Water evaporation" min:"0"`
+	WaterInfiltration float64 `param:"water_infiltration" label:"Water infiltration" min:"0"`
+	WaterFlowRate     float64 `param:"water_flow_rate" label:"Water flow rate" min:"0" max:"1" opts:"clamp"`
+	WaterLakeDepth    float64 `param:"water_lake_depth" label:"Water lake depth" min:"0"`
+	WaterRiverFlow    float64 `param:"water_river_flow" label:"Water river flow" min:"0"`
+
+	Erosion             bool    `param:"erosion" label:"Erosion" group:"Erosion"`
+	ErosionBudget       int     `param:"erosion_budget" label:"Erosion tiles per tick" min:"0"`
+	ErosionRainRate     float64 `param:"erosion_rain_rate" label:"Erosion rain rate" min:"0"`
+	ErosionFlowRate     float64 `param:"erosion_flow_rate" label:"Erosion flow rate" min:"0"`
+	ErosionThermalRate  float64 `param:"erosion_thermal_rate" label:"Erosion thermal rate" min:"0"`
+	ErosionTalus        float64 `param:"erosion_talus" label:"Erosion talus slope" min:"0"`
+	ErosionDepositSlope float64 `param:"erosion_deposit_slope" label:"Erosion deposit slope" min:"0"`
+	ErosionMountainWear float64 `param:"erosion_mountain_wear" label:"Mountain wear to rock" min:"0.01"`
+	ErosionRockWear     float64 `param:"erosion_rock_wear" label:"Rock wear to dirt" min:"0.01"`
+
+	RainMaxRegions  int     `param:"rain_max_regions" label:"Rain max regions" group:"Rain" min:"0"`
+	RainSpawnChance float64 `param:"rain_spawn_chance" label:"Rain spawn chance" opts:"percent,hud"`
+	RainRadiusMin   int     `param:"rain_radius_min" label:"Rain radius min" min:"0"`
+	RainRadiusMax   int     `param:"rain_radius_max" label:"Rain radius max" min:"0" floor:"rain_radius_min"`
+	RainTTLMin      int     `param:"rain_ttl_min" label:"Rain TTL min" min:"0"`
+	RainTTLMax      int     `param:"rain_ttl_max" label:"Rain TTL max" min:"0" floor:"rain_ttl_min"`
+	RainStrengthMin float64 `param:"rain_strength_min" label:"Rain strength min" min:"0" max:"1" opts:"clamp"`
+	RainStrengthMax float64 `param:"rain_strength_max" label:"Rain strength max" min:"0" max:"1" floor:"rain_strength_min" opts:"clamp,hud"`
+
+	Weather               bool    `param:"weather" label:"Weather systems" group:"Weather"`
+	WeatherCellSize       int     `param:"weather_cell_size" label:"Weather cell size" min:"2" max:"64" opts:"clamp"`
+	WeatherEvaporation    float64 `param:"weather_evaporation" label:"Evaporation rate" min:"0" max:"1" opts:"clamp,hud"`
+	WeatherSaturation     float64 `param:"weather_saturation" label:"Saturation humidity" min:"0.05" max:"2" opts:"clamp,hud"`
+	WeatherRainout        float64 `param:"weather_rainout" label:"Rainout rate" min:"0" max:"1" opts:"clamp"`
+	WeatherCondenseChance float64 `param:"weather_condense_chance" label:"Condense chance" opts:"percent,hud"`
+	WeatherPressureRelax  float64 `param:"weather_pressure_relax" label:"Pressure relaxation" min:"0" max:"1" opts:"clamp"`
+	WeatherFrontChance    float64 `param:"weather_front_chance" label:"Front spawn chance" opts:"percent,hud"`
+	WeatherFrontMax       int     `param:"weather_front_max" label:"Max fronts" min:"0"`
+	WeatherFrontSpeed     float64 `param:"weather_front_speed" label:"Front speed" min:"0" opts:"hud"`
+	WeatherFrontWidth     float64 `param:"weather_front_width" label:"Front width" min:"1"`
+	WeatherFrontDepth     float64 `param:"weather_front_depth" label:"Front pressure drop" min:"0" max:"0.5" opts:"clamp"`
+	WeatherOrographicLift float64 `param:"weather_orographic_lift" label:"Orographic lift" min:"0"`
+	WeatherOrographicRain float64 `param:"weather_orographic_rain" label:"Orographic rain" min:"0" opts:"hud"`
+
+	WindNoiseScale    float64 `param:"wind_noise_scale" label:"Wind noise scale" group:"Wind" min:"0" opts:"hud"`
+	WindSpeedScale    float64 `param:"wind_speed_scale" label:"Wind speed scale" min:"0" opts:"hud"`
+	WindTemporalScale float64 `param:"wind_temporal_scale" label:"Wind temporal scale" min:"0" max:"0.06" opts:"clamp,hud"`
+
+	SeasonLength           int     `param:"season_length" label:"Season length" group:"Climate" min:"0" step:"50" opts:"hud"`
+	DayLength              int     `param:"day_length" label:"Day length" min:"0"`
+	ClimateRainAmplitude   float64 `param:"climate_rain_amplitude" label:"Seasonal rain swing" min:"0" max:"1" opts:"clamp"`
+	ClimateWindAmplitude   float64 `param:"climate_wind_amplitude" label:"Seasonal wind swing" min:"0" max:"1" opts:"clamp"`
+	ClimateGrowthAmplitude float64 `param:"climate_growth_amplitude" label:"Seasonal growth swing" min:"0" max:"1" opts:"clamp"`
+	ClimateFireAmplitude   float64 `param:"climate_fire_amplitude" label:"Seasonal fire swing" min:"0" max:"1" opts:"clamp"`
+	ClimateDayAmplitude    float64 `param:"climate_day_amplitude" label:"Day/night swing" min:"0" max:"1" opts:"clamp"`
+
+	GrassNeighborThreshold int     `param:"grass_neighbor_threshold" label:"Grass neighbor threshold" group:"Vegetation" min:"0"`
+	GrassSpreadChance      float64 `param:"grass_spread_chance" label:"Grass spread chance" opts:"percent,hud"`
+	ShrubNeighborThreshold int     `param:"shrub_neighbor_threshold" label:"Shrub neighbor threshold" min:"0"`
+	ShrubGrowthChance      float64 `param:"shrub_growth_chance" label:"Shrub growth chance" opts:"percent,hud"`
+	TreeNeighborThreshold  int     `param:"tree_neighbor_threshold" label:"Tree neighbor threshold" min:"0"`
+	TreeGrowthChance       float64 `param:"tree_growth_chance" label:"Tree growth chance" opts:"percent"`
+
+	VegDroughtThreshold    float64 `param:"veg_drought_threshold" label:"Drought moisture threshold" group:"Mortality" min:"0" max:"1" opts:"clamp"`
+	VegDroughtDamage       float64 `param:"veg_drought_damage" label:"Drought damage" min:"0" max:"0.2" opts:"clamp,hud"`
+	VegHealthRecovery      float64 `param:"veg_health_recovery" label:"Health recovery" min:"0"`
+	TreeCrowdingThreshold  int     `param:"tree_crowding_threshold" label:"Tree crowding threshold" min:"0"`
+	TreeCrowdingDamage     float64 `param:"tree_crowding_damage" label:"Tree crowding damage" min:"0"`
+	TreeAgeMortalityStart  int     `param:"tree_age_mortality_start" label:"Tree age mortality start" min:"0"`
+	TreeAgeMortalityChance float64 `param:"tree_age_mortality_chance" label:"Tree age mortality chance" opts:"percent"`
+	TreeSeedChance         float64 `param:"tree_seed_chance" label:"Tree seed chance" group:"Dispersal" opts:"percent,hud"`
+	TreeSeedDistance       int     `param:"tree_seed_distance" label:"Tree seed distance" min:"1"`
+	TreeSeedWind           float64 `param:"tree_seed_wind" label:"Tree seed wind drift" min:"0"`
+	LavaRegrowthDelay      int     `param:"lava_regrowth_delay" label:"Lava regrowth delay" min:"0"`
+	LavaRegrowthChance     float64 `param:"lava_regrowth_chance" label:"Lava regrowth chance" opts:"percent"`
+	BasaltGrassFactor      float64 `param:"basalt_grass_factor" label:"Basalt grass factor" min:"0" max:"1" opts:"clamp"`
+
+	HerbivoreDensity         float64 `param:"herbivore_density" label:"Herbivore initial density" group:"Herbivores" min:"0" max:"1" opts:"clamp"`
+	HerbivoreMetabolism      float64 `param:"herbivore_metabolism" label:"Herbivore metabolism" min:"0"`
+	HerbivoreGrassGain       float64 `param:"herbivore_grass_gain" label:"Herbivore grass energy" min:"0"`
+	HerbivoreShrubGain       float64 `param:"herbivore_shrub_gain" label:"Herbivore shrub energy" min:"0"`
+	HerbivoreReproduceEnergy float64 `param:"herbivore_reproduce_energy" label:"Herbivore reproduce energy" min:"0"`
+	HerbivoreReproduceChance float64 `param:"herbivore_reproduce_chance" label:"Herbivore reproduce chance" opts:"percent,hud"`
+	HerbivoreMaxAge          int     `param:"herbivore_max_age" label:"Herbivore max age" min:"0"`
+	HerbivoreFleeRadius      int     `param:"herbivore_flee_radius" label:"Herbivore flee radius" min:"0"`
+	PredatorDensity          float64 `param:"predator_density" label:"Predator initial density" group:"Predators" min:"0" max:"1" opts:"clamp"`
+	PredatorMetabolism       float64 `param:"predator_metabolism" label:"Predator metabolism" min:"0"`
+	PredatorPreyGain         float64 `param:"predator_prey_gain" label:"Predator prey energy" min:"0"`
+	PredatorHuntChance       float64 `param:"predator_hunt_chance" label:"Predator hunt chance" opts:"percent,hud"`
+	PredatorReproduceEnergy  float64 `param:"predator_reproduce_energy" label:"Predator reproduce energy" min:"0"`
+	PredatorReproduceChance  float64 `param:"predator_reproduce_chance" label:"Predator reproduce chance" opts:"percent"`
+	PredatorMaxAge           int     `param:"predator_max_age" label:"Predator max age" min:"0"`
+	PredatorSenseRadius      int     `param:"predator_sense_radius" label:"Predator sense radius" min:"0"`
+
+	VolcanoProtoMaxRegions        int     `param:"volcano_proto_max_regions" label:"Volcano proto max regions" group:"Volcano" min:"0"`
+	VolcanoProtoSpawnChance       float64 `param:"volcano_proto_spawn_chance" label:"Volcano proto spawn chance" opts:"percent,hud"`
+	VolcanoProtoTectonicThreshold float64 `param:"volcano_proto_tectonic_threshold" label:"Volcano proto tectonic threshold" min:"0" max:"1" opts:"clamp"`
+	VolcanoProtoRadiusMin         int     `param:"volcano_proto_radius_min" label:"Volcano proto radius min" min:"0"`
+	VolcanoProtoRadiusMax         int     `param:"volcano_proto_radius_max" label:"Volcano proto radius max" min:"0" floor:"volcano_proto_radius_min"`
+	VolcanoProtoTTLMin            int     `param:"volcano_proto_ttl_min" label:"Volcano proto TTL min" min:"0"`
+	VolcanoProtoTTLMax            int     `param:"volcano_proto_ttl_max" label:"Volcano proto TTL max" min:"0" floor:"volcano_proto_ttl_min"`
+	VolcanoProtoStrengthMin       float64 `param:"volcano_proto_strength_min" label:"Volcano proto strength min" min:"0" max:"1" opts:"clamp,hud"`
+	VolcanoProtoStrengthMax       float64 `param:"volcano_proto_strength_max" label:"Volcano proto strength max" min:"0" max:"1" floor:"volcano_proto_strength_min" opts:"clamp,hud"`
+	VolcanoUpliftChanceBase       float64 `param:"volcano_uplift_chance_base" label:"Volcano uplift chance base" opts:"percent,hud"`
+	VolcanoEruptionChanceBase     float64 `param:"volcano_eruption_chance_base" label:"Volcano eruption chance base" opts:"percent,hud"`
 }
 
+// paramSchema drives parsing, snapshots, HUD controls, and setters for Params.
+var paramSchema = core.MustParamSchema(Params{})
+
 // Config controls the Ecology simulation dimensions.
 type Config struct {
 	Width  int
@@ -71,21 +226,65 @@ type Config struct {
 
 	Seed int64
 
+	// Terrain selects the Reset generator: TerrainFlat, TerrainNoise, or
+	// TerrainPlates.
+	Terrain string
+
+	// Topology selects how the map edges behave: TopologyBounded or
+	// TopologyTorus.
+	Topology string
+
+	// Preset names the parameter pack, built in or a file path, that
+	// overlays the defaults before any explicit keys apply.
+	Preset string
+
+	// GroundMap, VegetationMap, ElevationMap, and TectonicMap name PNG files
+	// that replace the generated layers at Reset. LoadConfig decodes them.
+	GroundMap     string
+	VegetationMap string
+	ElevationMap  string
+	TectonicMap   string
+
 	Params Params
+
+	maps *mapLayers
 }
 
 // DefaultConfig returns the standard configuration.
 func DefaultConfig() Config {
 	return Config{
-		Width:  256,
-		Height: 256,
-		Seed:   1337,
+		Width:    256,
+		Height:   256,
+		Seed:     1337,
+		Terrain:  TerrainFlat,
+		Topology: TopologyBounded,
 		Params: Params{
+			Rain:                          true,
+			Wind:                          true,
+			Volcanoes:                     true,
+			Lava:                          true,
+			Fire:                          true,
+			Succession:                    true,
+			Agents:                        true,
+			Soil:                          true,
 			RockChance:                    0.05,
 			GrassPatchCount:               12,
 			GrassPatchRadiusMin:           2,
 			GrassPatchRadiusMax:           5,
 			GrassPatchDensity:             0.6,
+			TerrainScale:                  0.012,
+			TerrainRelief:                 24,
+			TerrainWaterLevel:             0.3,
+			TerrainMountainLevel:          0.75,
+			TerrainPlateCount:             8,
+			BiomeLatitudeCooling:          0.85,
+			BiomeLapseRate:                0.4,
+			BiomeSnowLine:                 0.2,
+			BiomeTundraLine:               0.4,
+			BiomeDesertLine:               0.6,
+			BiomeDryMoisture:              0.4,
+			BiomeWetMoisture:              0.75,
+			BiomeMoistureNoise:            0.4,
 			LavaSpreadChance:              0.08,
 			LavaSpreadMaskFloor:           0.2,
 			LavaFluxRef:                   2,
@@ -100,11 +299,58 @@ func DefaultConfig() Config {
 			LavaReservoirMax:              220,
 			LavaReservoirGain:             0.8,
 			LavaReservoirHead:             3.5,
+			LavaMaxHeight:                 7,
+			LavaOverflowHeight:            4,
+			LavaBaseSpeed:                 0.9,
+			LavaSpeedAlpha:                0.3,
+			LavaFlowThreshold:             0.9,
+			LavaSplitChance:               0.25,
+			LavaSplitMinHeight:            3,
+			LavaSplitThresholdDrop:        0.15,
+			LavaSlopeWeight:               1.0,
+			LavaAlignWeight:               0.6,
+			LavaChannelWeight:             0.8,
+			LavaRainWeight:                0.5,
+			LavaWallWeight:                2.0,
+			LavaChannelGrow:               0.15,
+			LavaChannelDecay:              0.005,
+			LavaTipTemperatureMin:         0.12,
+			LavaReheatCap:                 0.35,
 			BurnTTL:                       3,
 			FireSpreadChance:              0.25,
 			FireLavaIgniteChance:          0.8,
 			FireRainSpreadDampen:          0.75,
 			FireRainExtinguishChance:      0.5,
+			FireWindBias:                  0,
+			FireEmberChance:               0,
+			FireEmberDistance:             6,
+			FireSoilDampen:                0.5,
+			LightningChance:               0,
+			LightningSquallBoost:          4,
+			LightningEdgeBias:             0.7,
+			LightningDryChance:            0.15,
+			LightningIgniteChance:         0.5,
+			LightningFlashTicks:           3,
+			SoilMoistureInitial:           0.5,
+			SoilRainGain:                  0.1,
+			SoilEvaporation:               0.004,
+			SoilHeatEvaporation:           0.05,
+			SoilGrowthDry:                 0.25,
+			SoilGrowthWet:                 1.75,
+			WaterRainGain:                 0.01,
+			WaterEvaporation:              0.002,
+			WaterInfiltration:             0.02,
+			WaterFlowRate:                 0.5,
+			WaterLakeDepth:                0.6,
+			WaterRiverFlow:                0.03,
+			ErosionBudget:                 4096,
+			ErosionRainRate:               0.002,
+			ErosionFlowRate:               0.05,
+			ErosionThermalRate:            0.002,
+			ErosionTalus:                  3,
+			ErosionDepositSlope:           0.5,
+			ErosionMountainWear:           1.5,
+			ErosionRockWear:               3,
 			RainMaxRegions:                4,
 			RainSpawnChance:               0.22,
 			RainRadiusMin:                 16,
@@ -113,15 +359,62 @@ func DefaultConfig() Config {
 			RainTTLMax:                    30,
 			RainStrengthMin:               0.5,
 			RainStrengthMax:               1.0,
+			WeatherCellSize:               8,
+			WeatherEvaporation:            0.01,
+			WeatherSaturation:             0.7,
+			WeatherRainout:                0.05,
+			WeatherCondenseChance:         0.3,
+			WeatherPressureRelax:          0.02,
+			WeatherFrontChance:            0.01,
+			WeatherFrontMax:               2,
+			WeatherFrontSpeed:             0.5,
+			WeatherFrontWidth:             24,
+			WeatherFrontDepth:             0.3,
+			WeatherOrographicLift:         0.05,
+			WeatherOrographicRain:         0.5,
 			WindNoiseScale:                0.01,
 			WindSpeedScale:                0.6,
 			WindTemporalScale:             0.05,
+			ClimateRainAmplitude:          0.6,
+			ClimateWindAmplitude:          0.3,
+			ClimateGrowthAmplitude:        0.5,
+			ClimateFireAmplitude:          0.6,
+			ClimateDayAmplitude:           0.3,
 			GrassNeighborThreshold:        1,
 			GrassSpreadChance:             0.01,
 			ShrubNeighborThreshold:        3,
 			ShrubGrowthChance:             0.04,
 			TreeNeighborThreshold:         3,
 			TreeGrowthChance:              0.02,
+			VegDroughtThreshold:           0.15,
+			VegDroughtDamage:              0.02,
+			VegHealthRecovery:             0.01,
+			TreeCrowdingThreshold:         8,
+			TreeCrowdingDamage:            0.004,
+			TreeAgeMortalityStart:         1500,
+			TreeAgeMortalityChance:        0.002,
+			TreeSeedChance:                0.002,
+			TreeSeedDistance:              6,
+			TreeSeedWind:                  1.0,
+			LavaRegrowthDelay:             400,
+			LavaRegrowthChance:            0.005,
+			BasaltGrassFactor:             0.3,
+			HerbivoreDensity:              0,
+			HerbivoreMetabolism:           0.02,
+			HerbivoreGrassGain:            0.12,
+			HerbivoreShrubGain:            0.2,
+			HerbivoreReproduceEnergy:      1.2,
+			HerbivoreReproduceChance:      0.01,
+			HerbivoreMaxAge:               800,
+			HerbivoreFleeRadius:           3,
+			PredatorDensity:               0,
+			PredatorMetabolism:            0.004,
+			PredatorPreyGain:              0.5,
+			PredatorHuntChance:            0.15,
+			PredatorReproduceEnergy:       1.5,
+			PredatorReproduceChance:       0.02,
+			PredatorMaxAge:                1200,
+			PredatorSenseRadius:           10,
 			VolcanoProtoMaxRegions:        6,
 			VolcanoProtoSpawnChance:       0.02,
 			VolcanoProtoTectonicThreshold: 0.6,
@@ -138,324 +431,101 @@ func DefaultConfig() Config {
 }
 
 // FromMap populates the config from a string map (flag-style key/value pairs).
+// It is lenient: unknown keys and unparsable values are ignored and
+// out-of-range tunables are clamped or ignored as their schema declares. A
+// preset key overlays its pack beneath the explicit keys; a broken preset is
+// ignored.
 func FromMap(cfg map[string]string) Config {
-	c := DefaultConfig()
 	if cfg == nil {
-		return c
-	}
-	if v, ok := cfg["w"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
-			c.Width = parsed
-		}
-	}
-	if v, ok := cfg["h"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 {
-			c.Height = parsed
-		}
-	}
-	if v, ok := cfg["seed"]; ok {
-		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
-			c.Seed = parsed
-		}
-	}
-	if v, ok := cfg["rock_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.RockChance = parsed
-		}
-	}
-	if v, ok := cfg["grass_patch_count"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.GrassPatchCount = parsed
-		}
-	}
-	if v, ok := cfg["grass_patch_radius_min"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.GrassPatchRadiusMin = parsed
-		}
+		return DefaultConfig()
 	}
-	if v, ok := cfg["grass_patch_radius_max"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.GrassPatchRadiusMax = parsed
-		}
-	}
-	if c.Params.GrassPatchRadiusMax < c.Params.GrassPatchRadiusMin {
-		c.Params.GrassPatchRadiusMax = c.Params.GrassPatchRadiusMin
+	merged, err := withPreset(cfg)
+	c, _ := parseKeys(merged, false)
+	if err == nil {
+		c.Preset = cfg["preset"]
 	}
-	if v, ok := cfg["grass_patch_density"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			c.Params.GrassPatchDensity = parsed
-		}
-	}
-	if v, ok := cfg["lava_spread_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.LavaSpreadChance = parsed
-		}
+	return c
+}
+
+// ParseConfig is the strict counterpart of FromMap: it reports unknown keys,
+// unparsable values, out-of-range tunables, and broken presets instead of
+// skipping them.
+func ParseConfig(cfg map[string]string) (Config, error) {
+	merged, err := withPreset(cfg)
+	if err != nil {
+		return FromMap(cfg), err
+	}
+	c, err := parseKeys(merged, true)
+	c.Preset = cfg["preset"]
+	return c, err
+}
+
+// parseKeys builds a config from cfg, which already carries any preset
+// values; the preset key itself is not resolved again. Valid values are
+// always applied and every rejected key is reported. Strict parsing skips
+// out-of-range tunables, while lenient parsing clamps them where the schema
+// allows.
+func parseKeys(cfg map[string]string, strict bool) (Config, error) {
+	c := DefaultConfig()
+	keys := make([]string, 0, len(cfg))
+	for key := range cfg {
+		keys = append(keys, key)
 	}
-	if v, ok := cfg["lava_spread_mask_floor"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			if parsed < 0 {
-				parsed = 0
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		v := cfg[key]
+		if _, ok := paramSchema.Spec(key); ok {
+			continue
+		}
+		switch key {
+		case "w", "h":
+			parsed, err := strconv.Atoi(v)
+			if err != nil || parsed <= 0 {
+				errs = append(errs, fmt.Errorf("%s: expected a positive integer, got %q", key, v))
+			} else if key == "w" {
+				c.Width = parsed
+			} else {
+				c.Height = parsed
 			}
-			if parsed > 1 {
-				parsed = 1
+		case "seed":
+			if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+				c.Seed = parsed
+			} else {
+				errs = append(errs, fmt.Errorf("seed: invalid integer %q", v))
 			}
-			c.Params.LavaSpreadMaskFloor = parsed
-		}
-	}
-	if v, ok := cfg["lava_flux_ref"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed > 0 {
-			c.Params.LavaFluxRef = parsed
-		}
-	}
-	if v, ok := cfg["lava_cool_base"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.LavaCoolBase = parsed
-		}
-	}
-	if v, ok := cfg["lava_cool_rain"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.LavaCoolRain = parsed
-		}
-	}
-	if v, ok := cfg["lava_cool_edge"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.LavaCoolEdge = parsed
-		}
-	}
-	if v, ok := cfg["lava_cool_thick"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.LavaCoolThick = parsed
-		}
-	}
-	if v, ok := cfg["lava_cool_flux"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.LavaCoolFlux = parsed
-		}
-	}
-	if v, ok := cfg["lava_phase_threshold"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			c.Params.LavaPhaseThreshold = parsed
-		}
-	}
-	if v, ok := cfg["lava_phase_hysteresis"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.LavaPhaseHysteresis = parsed
-		}
-	}
-	if v, ok := cfg["lava_reservoir_min"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.LavaReservoirMin = parsed
-		}
-	}
-	if v, ok := cfg["lava_reservoir_max"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.LavaReservoirMax = parsed
-		}
-	}
-	if c.Params.LavaReservoirMax < c.Params.LavaReservoirMin {
-		c.Params.LavaReservoirMax = c.Params.LavaReservoirMin
-	}
-	if v, ok := cfg["lava_reservoir_gain"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.LavaReservoirGain = parsed
-		}
-	}
-	if v, ok := cfg["lava_reservoir_head"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			c.Params.LavaReservoirHead = parsed
-		}
-	}
-	if v, ok := cfg["burn_ttl"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.BurnTTL = parsed
-		}
-	}
-	if v, ok := cfg["fire_spread_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.FireSpreadChance = parsed
-		}
-	}
-	if v, ok := cfg["fire_lava_ignite_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.FireLavaIgniteChance = parsed
-		}
-	}
-	if v, ok := cfg["fire_rain_spread_dampen"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.FireRainSpreadDampen = parsed
-		}
-	}
-	if v, ok := cfg["fire_rain_extinguish_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.FireRainExtinguishChance = parsed
-		}
-	}
-	if v, ok := cfg["rain_max_regions"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.RainMaxRegions = parsed
-		}
-	}
-	if v, ok := cfg["rain_spawn_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.RainSpawnChance = parsed
-		}
-	}
-	if v, ok := cfg["rain_radius_min"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.RainRadiusMin = parsed
-		}
-	}
-	if v, ok := cfg["rain_radius_max"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.RainRadiusMax = parsed
-		}
-	}
-	if c.Params.RainRadiusMax < c.Params.RainRadiusMin {
-		c.Params.RainRadiusMax = c.Params.RainRadiusMin
-	}
-	if v, ok := cfg["rain_ttl_min"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.RainTTLMin = parsed
-		}
-	}
-	if v, ok := cfg["rain_ttl_max"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.RainTTLMax = parsed
-		}
-	}
-	if c.Params.RainTTLMax < c.Params.RainTTLMin {
-		c.Params.RainTTLMax = c.Params.RainTTLMin
-	}
-	if v, ok := cfg["rain_strength_min"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.RainStrengthMin = parsed
-		}
-	}
-	if v, ok := cfg["rain_strength_max"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.RainStrengthMax = parsed
-		}
-	}
-	if c.Params.RainStrengthMax < c.Params.RainStrengthMin {
-		c.Params.RainStrengthMax = c.Params.RainStrengthMin
-	}
-	if v, ok := cfg["wind_noise_scale"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			if parsed < 0 {
-				parsed = 0
+		case "terrain":
+			if validTerrain(v) {
+				c.Terrain = v
+			} else {
+				errs = append(errs, fmt.Errorf("terrain: unknown generator %q", v))
 			}
-			c.Params.WindNoiseScale = parsed
-		}
-	}
-	if v, ok := cfg["wind_speed_scale"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			if parsed < 0 {
-				parsed = 0
+		case "topology":
+			if validTopology(v) {
+				c.Topology = v
+			} else {
+				errs = append(errs, fmt.Errorf("topology: unknown mode %q", v))
 			}
-			c.Params.WindSpeedScale = parsed
-		}
-	}
-	if v, ok := cfg["wind_temporal_scale"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			if parsed < 0 {
-				parsed = 0
-			}
-			c.Params.WindTemporalScale = parsed
-		}
-	}
-	if v, ok := cfg["grass_neighbor_threshold"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.GrassNeighborThreshold = parsed
-		}
-	}
-	if v, ok := cfg["grass_spread_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.GrassSpreadChance = parsed
-		}
-	}
-	if v, ok := cfg["shrub_neighbor_threshold"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.ShrubNeighborThreshold = parsed
-		}
-	}
-	if v, ok := cfg["shrub_growth_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.ShrubGrowthChance = parsed
-		}
-	}
-	if v, ok := cfg["tree_neighbor_threshold"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.TreeNeighborThreshold = parsed
-		}
-	}
-	if v, ok := cfg["tree_growth_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.TreeGrowthChance = parsed
-		}
-	}
-	if v, ok := cfg["volcano_proto_max_regions"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.VolcanoProtoMaxRegions = parsed
-		}
-	}
-	if v, ok := cfg["volcano_proto_spawn_chance"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.VolcanoProtoSpawnChance = parsed
-		}
-	}
-	if v, ok := cfg["volcano_proto_tectonic_threshold"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			c.Params.VolcanoProtoTectonicThreshold = parsed
-		}
-	}
-	if v, ok := cfg["volcano_proto_radius_min"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.VolcanoProtoRadiusMin = parsed
-		}
-	}
-	if v, ok := cfg["volcano_proto_radius_max"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.VolcanoProtoRadiusMax = parsed
-		}
-	}
-	if c.Params.VolcanoProtoRadiusMax < c.Params.VolcanoProtoRadiusMin {
-		c.Params.VolcanoProtoRadiusMax = c.Params.VolcanoProtoRadiusMin
-	}
-	if v, ok := cfg["volcano_proto_ttl_min"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.VolcanoProtoTTLMin = parsed
-		}
-	}
-	if v, ok := cfg["volcano_proto_ttl_max"]; ok {
-		if parsed, err := strconv.Atoi(v); err == nil && parsed >= 0 {
-			c.Params.VolcanoProtoTTLMax = parsed
-		}
-	}
-	if c.Params.VolcanoProtoTTLMax < c.Params.VolcanoProtoTTLMin {
-		c.Params.VolcanoProtoTTLMax = c.Params.VolcanoProtoTTLMin
-	}
-	if v, ok := cfg["volcano_proto_strength_min"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			c.Params.VolcanoProtoStrengthMin = parsed
-		}
-	}
-	if v, ok := cfg["volcano_proto_strength_max"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil {
-			c.Params.VolcanoProtoStrengthMax = parsed
-		}
-	}
-	if c.Params.VolcanoProtoStrengthMax < c.Params.VolcanoProtoStrengthMin {
-		c.Params.VolcanoProtoStrengthMax = c.Params.VolcanoProtoStrengthMin
-	}
-	if v, ok := cfg["volcano_uplift_chance_base"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.VolcanoUpliftChanceBase = parsed
-		}
-	}
-	if v, ok := cfg["volcano_eruption_chance_base"]; ok {
-		if parsed, err := strconv.ParseFloat(v, 64); err == nil && parsed >= 0 {
-			c.Params.VolcanoEruptionChanceBase = parsed
-		}
-	}
-	return c
+		case "ground_map":
+			c.GroundMap = v
+		case "vegetation_map":
+			c.VegetationMap = v
+		case "elevation_map":
+			c.ElevationMap = v
+		case "tectonic_map":
+			c.TectonicMap = v
+		case "preset":
+		default:
+			errs = append(errs, fmt.Errorf("unknown parameter %q", key))
+		}
+	}
+	if strict {
+		if err := paramSchema.Parse(&c.Params, cfg); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		paramSchema.Apply(&c.Params, cfg)
+	}
+	return c, errors.Join(errs...)
 }
@@ -5,11 +5,20 @@ import (
 	"math"
 )
 
+// Display values pack a tile into one palette index. With the top bit clear
+// the low five bits hold the ground, so up to 32 ground types render over
+// four vegetation stages, and a bare tile's value equals its Ground. With the
+// top bit set the value is an overlay that hides the tile beneath: fire, a
+// lightning flash, or an agent.
 const (
-	displayGroundMask      = 0x03
-	displayVegetationShift = 2
-	displayVegetationMask  = 0x0c
-	displayBurningBit      = 0x10
+	displayGroundMask      = 0x1f
+	displayVegetationShift = 5
+	displayVegetationMask  = 0x60
+	displayOverlayBit      = 0x80
+	displayBurning         = displayOverlayBit | 0x01
+	displayFlash           = displayOverlayBit | 0x02
+	// displayAgentBase + kind is the overlay for an agent.
+	displayAgentBase = displayOverlayBit | 0x10
 )
 
 var ecologyPalette = buildEcologyPalette()
@@ -20,12 +29,24 @@ func (w *World) Palette() []color.RGBA {
 }
 
 func buildEcologyPalette() []color.RGBA {
-	palette := make([]color.RGBA, 32)
+	palette := make([]color.RGBA, 256)
 	for i := range palette {
-		ground := Ground(i & displayGroundMask)
-		veg := Vegetation((i & displayVegetationMask) >> displayVegetationShift)
-		burning := (i & displayBurningBit) != 0
-		palette[i] = toRGBA(paletteColorFor(ground, veg, burning))
+		if i&displayOverlayBit == 0 {
+			ground := Ground(i & displayGroundMask)
+			veg := Vegetation((i & displayVegetationMask) >> displayVegetationShift)
+			palette[i] = toRGBA(paletteColorFor(ground, veg, false))
+			continue
+		}
+		switch {
+		case i == displayBurning:
+			palette[i] = toRGBA(paletteColorFor(GroundDirt, VegetationNone, true))
+		case i == displayFlash:
+			palette[i] = color.RGBA{R: 240, G: 240, B: 255, A: 255}
+		case i > displayAgentBase && i <= displayAgentBase+int(AgentPredator):
+			palette[i] = toRGBA(agentColor(AgentKind(i - displayAgentBase)))
+		default:
+			palette[i] = color.RGBA{A: 255}
+		}
 	}
 	return palette
 }
@@ -40,6 +61,8 @@ func paletteColorFor(ground Ground, veg Vegetation, burning bool) color.NRGBA {
 	}
 
 	switch ground {
+	case GroundWater:
+		return color.NRGBA{R: 40, G: 95, B: 175, A: 255}
 	case GroundLava:
 		return color.NRGBA{R: 255, G: 90, B: 40, A: 255}
 	case GroundMountain:
@@ -48,6 +71,32 @@ func paletteColorFor(ground Ground, veg Vegetation, burning bool) color.NRGBA {
 			return blendColors(base, vegetationColor(veg), 0.55)
 		}
 		return base
+	case GroundBasalt:
+		base := color.NRGBA{R: 52, G: 50, B: 58, A: 255}
+		if veg != VegetationNone {
+			return blendColors(base, vegetationColor(veg), 0.6)
+		}
+		return base
+	case GroundSand:
+		base := color.NRGBA{R: 214, G: 196, B: 140, A: 255}
+		if veg != VegetationNone {
+			return blendColors(base, vegetationColor(veg), 0.6)
+		}
+		return base
+	case GroundSnow:
+		return color.NRGBA{R: 236, G: 240, B: 246, A: 255}
+	case GroundWetland:
+		base := color.NRGBA{R: 72, G: 92, B: 74, A: 255}
+		if veg != VegetationNone {
+			return blendColors(base, vegetationColor(veg), 0.7)
+		}
+		return base
+	case GroundTundra:
+		base := color.NRGBA{R: 128, G: 124, B: 104, A: 255}
+		if veg != VegetationNone {
+			return blendColors(base, vegetationColor(veg), 0.55)
+		}
+		return base
 	case GroundRock:
 		base := color.NRGBA{R: 130, G: 130, B: 130, A: 255}
 		if veg != VegetationNone {
@@ -78,6 +127,15 @@ func vegetationColor(veg Vegetation) color.NRGBA {
 	}
 }
 
+func agentColor(kind AgentKind) color.NRGBA {
+	switch kind {
+	case AgentPredator:
+		return color.NRGBA{R: 200, G: 40, B: 60, A: 255}
+	default:
+		return color.NRGBA{R: 235, G: 220, B: 170, A: 255}
+	}
+}
+
 func blendColors(base, overlay color.NRGBA, overlayWeight float64) color.NRGBA {
 	if overlayWeight <= 0 {
 		return base
@@ -98,16 +156,20 @@ func blendColors(base, overlay color.NRGBA, overlayWeight float64) color.NRGBA {
 }
 
 func encodeDisplayValue(ground Ground, veg Vegetation, burning bool) uint8 {
+	if burning {
+		return displayBurning
+	}
 	value := uint8(ground) & displayGroundMask
 	if veg != VegetationNone {
 		value |= (uint8(veg) << displayVegetationShift) & displayVegetationMask
 	}
-	if burning {
-		value |= displayBurningBit
-	}
 	return value
 }
 
+func encodeDisplayAgent(kind AgentKind) uint8 {
+	return displayAgentBase + uint8(kind)
+}
+
 func (w *World) rebuildDisplay() {
 	total := len(w.display)
 	if total == 0 {
@@ -123,42 +185,52 @@ func (w *World) rebuildDisplay() {
 		burnSpan = 1
 	}
 	invBurnSpan := 1.0 / float64(burnSpan)
+	invLavaSpan := 1.0 / float64(w.lavaMaxHeight())
 
-	for i := 0; i < total; i++ {
-		var ground Ground
-		if i < len(w.groundCurr) {
-			ground = w.groundCurr[i]
-		}
-		var veg Vegetation
-		if i < len(w.vegCurr) {
-			veg = w.vegCurr[i]
+	// Every layer is allocated with the display, so reslicing them to its
+	// length lets the loop run without per-layer bounds checks.
+	if len(w.groundCurr) < total || len(w.vegCurr) < total || len(w.burnTTL) < total ||
+		len(w.lightningFlash) < total || len(w.lavaTemp) < total || len(w.lavaHeight) < total {
+		return
+	}
+	display := w.display[:total]
+	heat := w.heatField[:total]
+	ground := w.groundCurr[:total]
+	veg := w.vegCurr[:total]
+	burnTTL := w.burnTTL[:total]
+	flashTTL := w.lightningFlash[:total]
+	lavaTemp := w.lavaTemp[:total]
+	lavaHeight := w.lavaHeight[:total]
+	flashSpan := float64(max(w.cfg.Params.LightningFlashTicks, 1))
+
+	for i := range display {
+		burn := burnTTL[i]
+		display[i] = encodeDisplayValue(ground[i], veg[i], burn > 0)
+		flash := 0.0
+		if flashTTL[i] > 0 {
+			display[i] = displayFlash
+			flash = float64(flashTTL[i]) / flashSpan
 		}
-		burning := false
-		if i < len(w.burnTTL) {
-			burning = w.burnTTL[i] > 0
+		if lavaTemp[i] <= 0 && lavaHeight[i] == 0 && burn == 0 && flash == 0 {
+			// Most tiles are cold; skip the blend.
+			heat[i] = 0
+			continue
 		}
-		w.display[i] = encodeDisplayValue(ground, veg, burning)
+		heat[i] = float32(math.Max(computeHeatIntensity(float64(lavaTemp[i]), lavaHeight[i], burn, invLavaSpan, invBurnSpan), clampFloat(flash, 0, 1)))
+	}
 
-		lavaTemp := float64(0)
-		if i < len(w.lavaTemp) {
-			lavaTemp = float64(w.lavaTemp[i])
-		}
-		lavaHeight := uint8(0)
-		if i < len(w.lavaHeight) {
-			lavaHeight = w.lavaHeight[i]
-		}
-		burnTTL := uint8(0)
-		if i < len(w.burnTTL) {
-			burnTTL = w.burnTTL[i]
+	for _, a := range w.agents {
+		idx := a.Y*w.w + a.X
+		if idx >= 0 && idx < total {
+			w.display[idx] = encodeDisplayAgent(a.Kind)
 		}
-		w.heatField[i] = float32(computeHeatIntensity(lavaTemp, lavaHeight, burnTTL, invBurnSpan))
 	}
 }
 
-func computeHeatIntensity(lavaTemp float64, lavaHeight uint8, burnTTL uint8, invBurnSpan float64) float64 {
+func computeHeatIntensity(lavaTemp float64, lavaHeight uint8, burnTTL uint8, invLavaSpan, invBurnSpan float64) float64 {
 	lavaComponent := clampFloat(lavaTemp, 0, 1)
 	if lavaHeight > 0 {
-		heightNorm := float64(lavaHeight) / float64(lavaMaxHeight)
+		heightNorm := min(float64(lavaHeight)*invLavaSpan, 1)
 		// Blend temperature and column thickness so tall flows stay vivid even as they cool.
 		blended := lavaComponent*0.65 + heightNorm*0.35
 		if blended < heightNorm {
@@ -3,6 +3,8 @@ package ecology
 import (
 	"math"
 	"math/rand"
+	"slices"
+	"time"
 
 	"mad-ca/internal/core"
 )
@@ -18,6 +20,16 @@ const (
 	GroundRock
 	GroundMountain
 	GroundLava
+	GroundWater
+	// GroundBasalt is solidified lava. It weathers back to dirt and can be
+	// colonised by grass in the meantime.
+	GroundBasalt
+	// Climate-zone biomes assigned when Params.Biomes is on; see groundTraits
+	// for their vegetation and fire rules.
+	GroundSand
+	GroundSnow
+	GroundWetland
+	GroundTundra
 )
 
 const (
@@ -32,6 +44,8 @@ type World struct {
 	cfg Config
 
 	w, h int
+	// torus wraps neighbourhoods and regions across the map edges.
+	torus bool
 
 	groundCurr     []Ground
 	groundNext     []Ground
@@ -50,21 +64,81 @@ type World struct {
 	lavaFluxOut    []float32
 	lavaChannel    []float32
 	lavaElevation  []int16
-	lavaNoise      []int8
-	burnTTL        []uint8
-	burnNext       []uint8
-	rainCurr       []float32
-	rainNext       []float32
-	rainScratch    []float32
-	volCurr        []float32
-	volNext        []float32
-	tectonic       []float32
-	display        []uint8
-	heatField      []float32
+	// terrainElevation is the generated ground height that eruption cones
+	// and uplift build on; zero for flat terrain.
+	terrainElevation []int16
+	// erosionDebt holds sub-unit elevation change not yet applied to the
+	// integer rasters; sediment is material in transit; erosionWear tracks
+	// material stripped from mountain and rock toward their next ground type.
+	erosionDebt   []float32
+	sediment      []float32
+	erosionWear   []float32
+	erosionCursor int
+	// climateTick drives the seasonal and day/night cycles; climate caches
+	// the state derived from it for the current tick.
+	climateTick   int
+	climate       ClimateState
+	erosionEvents erosionEvents
+	// lightningFlash counts down the ticks a struck tile stays lit.
+	lightningFlash  []uint8
+	lightningEvents lightningEvents
+	lavaNoise       []int8
+	burnTTL         []uint8
+	burnNext        []uint8
+	rainCurr        []float32
+	rainNext        []float32
+	rainScratch     []float32
+	volCurr         []float32
+	volNext         []float32
+	tectonic        []float32
+	display         []uint8
+	heatField       []float32
+	soilMoisture    []float32
+	vegAge          []int16
+	vegHealth       []float32
+	lavaScar        []uint16
+	// basaltDepth records the lava thickness that has solidified on each
+	// tile over the run, the flow history overlay.
+	basaltDepth []int16
+	// climateZone is the biome ground each tile belongs to, restored when
+	// water drains or rock breaks down. All dirt with biomes off.
+	climateZone []Ground
+	agentCell   []int32
+	waterDepth  []float32
+	waterNext   []float32
+	waterFlow   []float32
+
+	// weather carries the humidity and pressure grids and fronts that
+	// condense rain regions when Params.Weather is on.
+	weather weatherState
+
+	fieldScratch map[string][]float32
+
+	// scratch holds buffers reused by the per-tick passes.
+	scratch stepScratch
+
+	// profile times each phase of Step for PhaseTimings.
+	profile stepProfile
 
 	rng *rand.Rand
+	// seed is the effective seed of the last Reset.
+	seed int64
 
-	metrics VegetationMetrics
+	// streams are the per-subsystem random streams, and running the
+	// switches in force last tick.
+	streams rngStreams
+	running subsystemSwitches
+
+	metrics    VegetationMetrics
+	vegEvents  vegetationEvents
+	population PopulationMetrics
+
+	// censusSource is the vegetation buffer whose tile census and cluster
+	// histogram are still owed to metrics; nil once vegetationMetrics has
+	// taken it.
+	censusSource []Vegetation
+
+	agents []Agent
 
 	rainRegions          []rainRegion
 	volcanoRegions       []volcanoProtoRegion
@@ -76,6 +150,10 @@ type World struct {
 	lavaTipQueue      []int
 	lavaFailedTips    []int
 	lavaAdvancedCells []int
+
+	// lavaSettled records that the last full lava pass left no lava and no
+	// vents, so every per-tile lava field is already at rest.
+	lavaSettled bool
 }
 
 // VegetationMetrics captures aggregate vegetation telemetry for the current tick.
@@ -86,6 +164,19 @@ type VegetationMetrics struct {
 
 	TotalVegetated int
 
+	// MeanAge and MeanHealth average plant age (ticks) and health (0..1)
+	// across vegetated tiles.
+	MeanAge    float64
+	MeanHealth float64
+
+	// Turnover counts the events of the last tick: deaths by cause, tree
+	// seedlings established at range, and cooled lava weathered back to grass.
+	DroughtDeaths        int
+	CrowdingDeaths       int
+	AgeDeaths            int
+	SeedlingsEstablished int
+	LavaRegrowth         int
+
 	// ClusterHistogram stores the count of Moore-connected components by size.
 	// Index represents the component size; index 0 is unused.
 	ClusterHistogram []int
@@ -97,14 +188,40 @@ type EnvironmentMetrics struct {
 	RockTiles     int
 	MountainTiles int
 	LavaTiles     int
+	BasaltTiles   int
+	SandTiles     int
+	SnowTiles     int
+	WetlandTiles  int
+	TundraTiles   int
 
 	BurningTiles int
 
 	RainCoverage      int
 	ActiveRainRegions int
+	WeatherFronts     int
 	RainMean          float64
 	RainMax           float64
 
+	// WaterTiles counts open-water ground; WaterVolume sums surface water depth.
+	WaterTiles  int
+	WaterVolume float64
+
+	// Eroded and Deposited report elevation units moved by erosion during the
+	// last tick; SedimentLoad sums material still in transit.
+	Eroded       float64
+	Deposited    float64
+	SedimentLoad float64
+
+	// LightningStrikes counts strikes during the last tick; LightningFires
+	// counts the strikes that set vegetation alight.
+	LightningStrikes int
+	LightningFires   int
+
+	// SoilMoistureMean averages the soil-moisture layer; DrySoilTiles counts
+	// tiles below the drought threshold.
+	SoilMoistureMean float64
+	DrySoilTiles     int
+
 	TotalTiles int
 }
 
@@ -164,26 +281,6 @@ type lavaCandidate struct {
 	score float64
 }
 
-const (
-	lavaMaxHeight          = 7
-	lavaFlowThreshold      = 0.9
-	lavaSplitThresholdDrop = 0.15
-	lavaSplitMinHeight     = 3
-	lavaSplitChance        = 0.25
-	lavaOverflowHeight     = 4
-	lavaBaseSpeed          = 0.9
-	lavaSpeedAlpha         = 0.3
-	lavaTipTemperatureMin  = 0.12
-	lavaReheatCap          = 0.35
-	lavaSlopeWeight        = 1.0
-	lavaAlignWeight        = 0.6
-	lavaChannelWeight      = 0.8
-	lavaRainWeight         = 0.5
-	lavaWallWeight         = 2.0
-	lavaChannelGrow        = 0.15
-	lavaChannelDecay       = 0.005
-)
-
 var lavaDirections = [...]lavaDirection{
 	{dx: 1, dy: 0, ux: 1, uy: 0},
 	{dx: 1, dy: 1, ux: 1 / math.Sqrt2, uy: 1 / math.Sqrt2},
@@ -234,6 +331,12 @@ func lavaSigmoid(x float64) float64 {
 	return 1 - math.Exp(-x)
 }
 
+// lavaMaxHeight bounds lava column heights to LavaMaxHeight, kept within the
+// uint8 height buffers.
+func (w *World) lavaMaxHeight() int {
+	return min(max(w.cfg.Params.LavaMaxHeight, 1), 255)
+}
+
 func (w *World) setLavaCell(idx int, height int, temp float32, dir int8, tip bool) {
 	if idx < 0 || idx >= len(w.groundCurr) {
 		return
@@ -272,8 +375,8 @@ func (w *World) setLavaCell(idx int, height int, temp float32, dir int8, tip boo
 		}
 		return
 	}
-	if height > lavaMaxHeight {
-		height = lavaMaxHeight
+	if maxHeight := w.lavaMaxHeight(); height > maxHeight {
+		height = maxHeight
 	}
 	if temp < 0 {
 		temp = 0
@@ -306,7 +409,7 @@ func (w *World) setLavaCell(idx int, height int, temp float32, dir int8, tip boo
 	if idx < len(w.lavaTipNext) {
 		w.lavaTipNext[idx] = tip
 	}
-	overflow := height >= lavaOverflowHeight
+	overflow := height >= w.cfg.Params.LavaOverflowHeight
 	if idx < len(w.lavaForce) {
 		w.lavaForce[idx] = overflow
 	}
@@ -343,10 +446,10 @@ func (w *World) buildLavaElevation(region volcanoProtoRegion) {
 		openings = make([]struct {
 			center    float64
 			halfWidth float64
-		}, 1+w.rng.Intn(3))
+		}, 1+w.streams.volcano.Intn(3))
 		for i := range openings {
-			widthDeg := float64(12 + w.rng.Intn(19))
-			openings[i].center = w.rng.Float64() * 2 * math.Pi
+			widthDeg := float64(12 + w.streams.volcano.Intn(19))
+			openings[i].center = w.streams.volcano.Float64() * 2 * math.Pi
 			openings[i].halfWidth = (widthDeg * math.Pi / 180) / 2
 		}
 	}
@@ -374,7 +477,7 @@ func (w *World) buildLavaElevation(region volcanoProtoRegion) {
 	if headLevel < 0 {
 		headLevel = 0
 	}
-	slopeScale := float64(20 + w.rng.Intn(21))
+	slopeScale := float64(20 + w.streams.volcano.Intn(21))
 	if slopeScale <= 0 {
 		slopeScale = 20
 	}
@@ -382,16 +485,17 @@ func (w *World) buildLavaElevation(region volcanoProtoRegion) {
 	cy := region.cy
 	radius := math.Max(region.radius, 0)
 	effectRadius := radius + 2*radius
-	minX := int(math.Max(0, math.Floor(cx-effectRadius)))
-	maxX := int(math.Min(float64(w.w-1), math.Ceil(cx+effectRadius)))
-	minY := int(math.Max(0, math.Floor(cy-effectRadius)))
-	maxY := int(math.Min(float64(w.h-1), math.Ceil(cy+effectRadius)))
+	minX, maxX := w.tileSpan(int(math.Floor(cx-effectRadius)), int(math.Ceil(cx+effectRadius)), w.w)
+	minY, maxY := w.tileSpan(int(math.Floor(cy-effectRadius)), int(math.Ceil(cy+effectRadius)), w.h)
 	if minX > maxX || minY > maxY {
 		return
 	}
 	for y := minY; y <= maxY; y++ {
 		for x := minX; x <= maxX; x++ {
-			idx := y*w.w + x
+			idx, ok := w.tileIndex(x, y)
+			if !ok {
+				continue
+			}
 			dx := (float64(x) + 0.5) - cx
 			dy := (float64(y) + 0.5) - cy
 			dist := math.Sqrt(dx*dx + dy*dy)
@@ -399,13 +503,17 @@ func (w *World) buildLavaElevation(region volcanoProtoRegion) {
 				continue
 			}
 
-			base := int16(3)
+			ground := int16(0)
+			if idx < len(w.terrainElevation) {
+				ground = w.terrainElevation[idx]
+			}
+			base := ground + 3
 			if idx < len(w.groundCurr) {
 				switch w.groundCurr[idx] {
 				case GroundRock:
-					base = 4
+					base = ground + 4
 				case GroundMountain:
-					base = 6
+					base = ground + 6
 				}
 			}
 			noise := int16(0)
@@ -416,8 +524,8 @@ func (w *World) buildLavaElevation(region volcanoProtoRegion) {
 			slope := int16(math.Round(dist / slopeScale))
 			elev := base + noise - slope
 			if dist <= region.radius*1.2 && inOpening(angle) {
-				if elev > headLevel {
-					elev = headLevel
+				if elev > ground+headLevel {
+					elev = ground + headLevel
 				}
 			}
 			if idx < len(w.lavaElevation) && elev > w.lavaElevation[idx] {
@@ -453,10 +561,8 @@ func (w *World) clearLavaFieldInRegion(region volcanoProtoRegion) float64 {
 		clearRadius = radius
 	}
 
-	minX := int(math.Max(0, math.Floor(region.cx-clearRadius)))
-	maxX := int(math.Min(float64(w.w-1), math.Ceil(region.cx+clearRadius)))
-	minY := int(math.Max(0, math.Floor(region.cy-clearRadius)))
-	maxY := int(math.Min(float64(w.h-1), math.Ceil(region.cy+clearRadius)))
+	minX, maxX := w.tileSpan(int(math.Floor(region.cx-clearRadius)), int(math.Ceil(region.cx+clearRadius)), w.w)
+	minY, maxY := w.tileSpan(int(math.Floor(region.cy-clearRadius)), int(math.Ceil(region.cy+clearRadius)), w.h)
 
 	if minX > maxX || minY > maxY {
 		return clearRadius
@@ -466,8 +572,8 @@ func (w *World) clearLavaFieldInRegion(region volcanoProtoRegion) float64 {
 
 	for y := minY; y <= maxY; y++ {
 		for x := minX; x <= maxX; x++ {
-			idx := y*w.w + x
-			if idx < 0 || idx >= len(w.groundCurr) {
+			idx, ok := w.tileIndex(x, y)
+			if !ok || idx >= len(w.groundCurr) {
 				continue
 			}
 			dx := (float64(x) + 0.5) - region.cx
@@ -504,8 +610,8 @@ func (w *World) removeLavaVentsInRadius(cx, cy, radius float64) {
 		}
 		x := float64(vent.idx%w.w) + 0.5
 		y := float64(vent.idx/w.w) + 0.5
-		dx := x - cx
-		dy := y - cy
+		dx := w.wrapDelta(x-cx, w.w)
+		dy := w.wrapDelta(y-cy, w.h)
 		if dx*dx+dy*dy <= radiusSq {
 			continue
 		}
@@ -515,26 +621,40 @@ func (w *World) removeLavaVentsInRadius(cx, cy, radius float64) {
 }
 
 func (w *World) pickDownhill(idx int) (int, int8, bool) {
-	if idx < 0 || idx >= len(w.lavaElevation) || w.w == 0 {
+	if idx < 0 || idx >= len(w.lavaElevation) {
+		return -1, -1, false
+	}
+	bestIdx, bestDir, drop := w.steepestDescent(idx, func(i int) float64 {
+		return float64(w.lavaElevation[i])
+	})
+	if bestIdx < 0 {
 		return -1, -1, false
 	}
-	base := w.lavaElevation[idx]
+	return bestIdx, bestDir, drop > 0
+}
+
+// steepestDescent returns the neighbour of idx with the largest drop in
+// height, its lavaDirections index, and the drop itself. The drop may be zero
+// or negative when idx sits in a pit; the index is -1 only when idx has no
+// in-bounds neighbours.
+func (w *World) steepestDescent(idx int, height func(int) float64) (int, int8, float64) {
+	if idx < 0 || idx >= w.w*w.h || w.w == 0 {
+		return -1, -1, 0
+	}
+	base := height(idx)
 	x := idx % w.w
 	y := idx / w.w
 	bestIdx := -1
 	bestDir := int8(-1)
-	var bestDrop int16 = math.MinInt16
+	bestDrop := math.Inf(-1)
 	for dirIdx, dir := range lavaDirections {
 		nx := x + dir.dx
 		ny := y + dir.dy
-		if nx < 0 || nx >= w.w || ny < 0 || ny >= w.h {
+		nIdx, ok := w.tileIndex(nx, ny)
+		if !ok {
 			continue
 		}
-		nIdx := ny*w.w + nx
-		if nIdx < 0 || nIdx >= len(w.lavaElevation) {
-			continue
-		}
-		drop := base - w.lavaElevation[nIdx]
+		drop := base - height(nIdx)
 		if drop > bestDrop {
 			bestDrop = drop
 			bestIdx = nIdx
@@ -542,9 +662,9 @@ func (w *World) pickDownhill(idx int) (int, int8, bool) {
 		}
 	}
 	if bestIdx < 0 {
-		return -1, -1, false
+		return -1, -1, 0
 	}
-	return bestIdx, bestDir, bestDrop > 0
+	return bestIdx, bestDir, bestDrop
 }
 
 type rainPreset int
@@ -555,298 +675,43 @@ const (
 	rainPresetSquall
 )
 
-// ParameterControls exposes the key ecology parameters that should be
-// adjustable from the HUD.
+// ParameterControls exposes the preset selector and the ecology parameters
+// tagged for the HUD.
 func (w *World) ParameterControls() []core.ParameterControl {
-	return []core.ParameterControl{
-		{
-			Key:    "grass_spread_chance",
-			Label:  "Grass spread chance",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    100,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "shrub_growth_chance",
-			Label:  "Shrub growth chance",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    100,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "fire_spread_chance",
-			Label:  "Fire spread chance",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    100,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "fire_rain_spread_dampen",
-			Label:  "Rain dampen factor",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    1,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "rain_spawn_chance",
-			Label:  "Rain spawn chance",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    100,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "rain_strength_max",
-			Label:  "Rain strength max",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    1,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "wind_noise_scale",
-			Label:  "Wind noise scale",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			HasMin: true,
-		},
-		{
-			Key:    "wind_speed_scale",
-			Label:  "Wind speed scale",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			HasMin: true,
-		},
-		{
-			Key:    "wind_temporal_scale",
-			Label:  "Wind temporal scale",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    0.06,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "lava_spread_chance",
-			Label:  "Lava spread chance",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    100,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "lava_cool_base",
-			Label:  "Lava base cooling",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    0.1,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "lava_cool_flux",
-			Label:  "Lava flux cooling",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    0.1,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "lava_reservoir_head",
-			Label:  "Lava reservoir head",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    6,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "lava_flux_ref",
-			Label:  "Lava flux reference",
-			Type:   core.ParamTypeFloat,
-			Min:    0.1,
-			Max:    8,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "volcano_proto_spawn_chance",
-			Label:  "Volcano proto spawn chance",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    100,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "volcano_proto_strength_min",
-			Label:  "Volcano proto strength min",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    1,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "volcano_proto_strength_max",
-			Label:  "Volcano proto strength max",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    1,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "volcano_uplift_chance_base",
-			Label:  "Volcano uplift chance",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    100,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "volcano_eruption_chance_base",
-			Label:  "Volcano eruption chance",
-			Type:   core.ParamTypeFloat,
-			Min:    0,
-			Max:    100,
-			HasMin: true,
-			HasMax: true,
-		},
-		{
-			Key:    "burn_ttl",
-			Label:  "Burn duration",
-			Type:   core.ParamTypeInt,
-			Step:   1,
-			Min:    1,
-			HasMin: true,
-		},
+	preset := core.ParameterControl{
+		Key:     "preset",
+		Label:   "Preset",
+		Type:    core.ParamTypeString,
+		Options: PresetNames(),
 	}
+	return append([]core.ParameterControl{preset}, paramSchema.Controls()...)
 }
 
 // SetIntParameter allows HUD interactions to update integer ecology parameters.
 func (w *World) SetIntParameter(key string, value int) bool {
-	if w == nil {
-		return false
-	}
-	switch key {
-	case "burn_ttl":
-		if value < 1 {
-			value = 1
-		}
-		w.cfg.Params.BurnTTL = value
-		return true
-	default:
+	if w == nil || !paramSchema.SetInt(&w.cfg.Params, key, value) {
 		return false
 	}
+	// The climate state is cached per tick; refresh it in case a climate
+	// tunable changed.
+	w.updateClimate()
+	return true
 }
 
-// SetFloatParameter allows HUD interactions to update float ecology parameters.
+// SetFloatParameter allows HUD interactions to update float ecology
+// parameters. Percent parameters take 0–100.
 func (w *World) SetFloatParameter(key string, value float64) bool {
-	if w == nil {
-		return false
-	}
-	switch key {
-	case "grass_spread_chance":
-		w.cfg.Params.GrassSpreadChance = percentToProbability(value)
-		return true
-	case "shrub_growth_chance":
-		w.cfg.Params.ShrubGrowthChance = percentToProbability(value)
-		return true
-	case "fire_spread_chance":
-		w.cfg.Params.FireSpreadChance = percentToProbability(value)
-		return true
-	case "fire_rain_spread_dampen":
-		w.cfg.Params.FireRainSpreadDampen = clampFloat(value, 0, 1)
-		return true
-	case "rain_spawn_chance":
-		w.cfg.Params.RainSpawnChance = percentToProbability(value)
-		return true
-	case "rain_strength_max":
-		clamped := clampFloat(value, 0, 1)
-		if clamped < w.cfg.Params.RainStrengthMin {
-			clamped = w.cfg.Params.RainStrengthMin
-		}
-		w.cfg.Params.RainStrengthMax = clamped
-		return true
-	case "wind_noise_scale":
-		if value < 0 {
-			value = 0
-		}
-		w.cfg.Params.WindNoiseScale = value
-		return true
-	case "wind_speed_scale":
-		if value < 0 {
-			value = 0
-		}
-		w.cfg.Params.WindSpeedScale = value
-		return true
-	case "wind_temporal_scale":
-		if value < 0 {
-			value = 0
-		}
-		w.cfg.Params.WindTemporalScale = value
-		return true
-	case "lava_spread_chance":
-		w.cfg.Params.LavaSpreadChance = percentToProbability(value)
-		return true
-	case "lava_cool_base":
-		w.cfg.Params.LavaCoolBase = clampFloat(value, 0, 0.1)
-		return true
-	case "lava_cool_flux":
-		w.cfg.Params.LavaCoolFlux = clampFloat(value, 0, 0.1)
-		return true
-	case "lava_reservoir_head":
-		w.cfg.Params.LavaReservoirHead = clampFloat(value, 0, 6)
-		return true
-	case "lava_flux_ref":
-		if value < 0.1 {
-			value = 0.1
-		}
-		if value > 8 {
-			value = 8
-		}
-		w.cfg.Params.LavaFluxRef = value
-		return true
-	case "volcano_proto_spawn_chance":
-		w.cfg.Params.VolcanoProtoSpawnChance = percentToProbability(value)
-		return true
-	case "volcano_proto_strength_min":
-		clamped := clampFloat(value, 0, 1)
-		if clamped > w.cfg.Params.VolcanoProtoStrengthMax {
-			clamped = w.cfg.Params.VolcanoProtoStrengthMax
-		}
-		w.cfg.Params.VolcanoProtoStrengthMin = clamped
-		return true
-	case "volcano_proto_strength_max":
-		clamped := clampFloat(value, 0, 1)
-		if clamped < w.cfg.Params.VolcanoProtoStrengthMin {
-			w.cfg.Params.VolcanoProtoStrengthMin = clamped
-		}
-		w.cfg.Params.VolcanoProtoStrengthMax = clamped
-		return true
-	case "volcano_uplift_chance_base":
-		w.cfg.Params.VolcanoUpliftChanceBase = percentToProbability(value)
-		return true
-	case "volcano_eruption_chance_base":
-		w.cfg.Params.VolcanoEruptionChanceBase = percentToProbability(value)
-		return true
-	default:
+	if w == nil || !paramSchema.SetFloat(&w.cfg.Params, key, value) {
 		return false
 	}
+	w.updateClimate()
+	return true
+}
+
+// SetBoolParameter allows HUD interactions to switch ecology subsystems on
+// or off. A disabled subsystem is skipped from the next tick on.
+func (w *World) SetBoolParameter(key string, value bool) bool {
+	return w != nil && paramSchema.SetBool(&w.cfg.Params, key, value)
 }
 
 // New returns an Ecology simulation with the provided dimensions using defaults.
@@ -864,39 +729,60 @@ func NewWithConfig(cfg Config) *World {
 		total = 0
 	}
 	w := &World{
-		cfg:            cfg,
-		w:              cfg.Width,
-		h:              cfg.Height,
-		groundCurr:     make([]Ground, total),
-		groundNext:     make([]Ground, total),
-		vegCurr:        make([]Vegetation, total),
-		vegNext:        make([]Vegetation, total),
-		lavaHeight:     make([]uint8, total),
-		lavaHeightNext: make([]uint8, total),
-		lavaTemp:       make([]float32, total),
-		lavaTempNext:   make([]float32, total),
-		lavaDir:        make([]int8, total),
-		lavaDirNext:    make([]int8, total),
-		lavaTip:        make([]bool, total),
-		lavaTipNext:    make([]bool, total),
-		lavaForce:      make([]bool, total),
-		lavaForceNext:  make([]bool, total),
-		lavaFluxOut:    make([]float32, total),
-		lavaChannel:    make([]float32, total),
-		lavaElevation:  make([]int16, total),
-		lavaNoise:      make([]int8, total),
-		burnTTL:        make([]uint8, total),
-		burnNext:       make([]uint8, total),
-		rainCurr:       make([]float32, total),
-		rainNext:       make([]float32, total),
-		rainScratch:    make([]float32, total),
-		volCurr:        make([]float32, total),
-		volNext:        make([]float32, total),
-		tectonic:       loadTectonicMap(cfg.Width, cfg.Height),
-		display:        make([]uint8, total),
-		heatField:      make([]float32, total),
-		rng:            rand.New(rand.NewSource(cfg.Seed)),
-	}
+		cfg:              cfg,
+		w:                cfg.Width,
+		h:                cfg.Height,
+		torus:            cfg.Topology == TopologyTorus,
+		groundCurr:       make([]Ground, total),
+		groundNext:       make([]Ground, total),
+		vegCurr:          make([]Vegetation, total),
+		vegNext:          make([]Vegetation, total),
+		lavaHeight:       make([]uint8, total),
+		lavaHeightNext:   make([]uint8, total),
+		lavaTemp:         make([]float32, total),
+		lavaTempNext:     make([]float32, total),
+		lavaDir:          make([]int8, total),
+		lavaDirNext:      make([]int8, total),
+		lavaTip:          make([]bool, total),
+		lavaTipNext:      make([]bool, total),
+		lavaForce:        make([]bool, total),
+		lavaForceNext:    make([]bool, total),
+		lavaFluxOut:      make([]float32, total),
+		lavaChannel:      make([]float32, total),
+		lavaElevation:    make([]int16, total),
+		terrainElevation: make([]int16, total),
+		erosionDebt:      make([]float32, total),
+		sediment:         make([]float32, total),
+		erosionWear:      make([]float32, total),
+		lightningFlash:   make([]uint8, total),
+		lavaNoise:        make([]int8, total),
+		burnTTL:          make([]uint8, total),
+		burnNext:         make([]uint8, total),
+		rainCurr:         make([]float32, total),
+		rainNext:         make([]float32, total),
+		rainScratch:      make([]float32, total),
+		volCurr:          make([]float32, total),
+		volNext:          make([]float32, total),
+		tectonic:         loadTectonicMap(cfg.Width, cfg.Height),
+		display:          make([]uint8, total),
+		heatField:        make([]float32, total),
+		soilMoisture:     make([]float32, total),
+		vegAge:           make([]int16, total),
+		vegHealth:        make([]float32, total),
+		lavaScar:         make([]uint16, total),
+		basaltDepth:      make([]int16, total),
+		climateZone:      make([]Ground, total),
+		agentCell:        make([]int32, total),
+		waterDepth:       make([]float32, total),
+		waterNext:        make([]float32, total),
+		waterFlow:        make([]float32, total),
+		rng:              rand.New(rand.NewSource(cfg.Seed)),
+		streams:          newRNGStreams(cfg.Seed),
+	}
+	if cfg.maps != nil && len(cfg.maps.tectonic) == total {
+		copy(w.tectonic, cfg.maps.tectonic)
+	}
+	w.updateClimate()
 	return w
 }
 
@@ -949,8 +835,18 @@ func (w *World) Reset(seed int64) {
 	if effective == 0 {
 		effective = w.cfg.Seed
 	}
+	w.seed = effective
 	w.rng.Seed(effective)
+	w.streams.reseed(effective)
+	w.running = subsystemSwitches{rain: true, volcanoes: true, fire: true}
+	w.torus = w.cfg.Topology == TopologyTorus
 	w.windPhase = 0
+	w.erosionCursor = 0
+	w.erosionEvents = erosionEvents{}
+	w.lightningEvents = lightningEvents{}
+	w.weather = weatherState{}
+	w.climateTick = 0
+	w.updateClimate()
 	total := w.w * w.h
 	for i := 0; i < total; i++ {
 		w.groundCurr[i] = GroundDirt
@@ -970,6 +866,11 @@ func (w *World) Reset(seed int64) {
 		w.lavaFluxOut[i] = 0
 		w.lavaChannel[i] = 0
 		w.lavaElevation[i] = 0
+		w.terrainElevation[i] = 0
+		w.erosionDebt[i] = 0
+		w.sediment[i] = 0
+		w.erosionWear[i] = 0
+		w.lightningFlash[i] = 0
 		if i < len(w.lavaNoise) {
 			w.lavaNoise[i] = int8(w.rng.Intn(3)) - 1
 		}
@@ -982,15 +883,32 @@ func (w *World) Reset(seed int64) {
 		}
 		w.volCurr[i] = 0
 		w.volNext[i] = 0
+		w.soilMoisture[i] = float32(w.cfg.Params.SoilMoistureInitial)
+		w.vegAge[i] = 0
+		w.vegHealth[i] = 1
+		w.lavaScar[i] = 0
+		w.basaltDepth[i] = 0
+		w.waterDepth[i] = 0
+		w.waterNext[i] = 0
+		w.waterFlow[i] = 0
 		w.display[i] = uint8(GroundDirt)
 	}
 
-	w.sprinkleRock()
-	w.seedGrassPatches()
+	w.generateTerrain(effective)
+	groundMapped, vegetationMapped := w.applyMapLayers()
+	if !groundMapped {
+		w.sprinkleRock()
+	}
+	if !vegetationMapped {
+		w.seedGrassPatches()
+	}
+	w.assignClimateZones(effective, !groundMapped)
+	w.spawnAgents()
 	copy(w.groundNext, w.groundCurr)
 	copy(w.vegNext, w.vegCurr)
 
 	w.metrics = VegetationMetrics{}
+	w.vegEvents = vegetationEvents{}
 	w.updateMetrics(w.vegCurr)
 
 	w.rebuildDisplay()
@@ -1002,6 +920,8 @@ func (w *World) Reset(seed int64) {
 	w.lavaTipQueue = w.lavaTipQueue[:0]
 	w.lavaFailedTips = w.lavaFailedTips[:0]
 	w.lavaAdvancedCells = w.lavaAdvancedCells[:0]
+	w.lavaSettled = false
+	w.profile = stepProfile{}
 }
 
 func clampFloat(value, min, max float64) float64 {
@@ -1014,78 +934,92 @@ func clampFloat(value, min, max float64) float64 {
 	return value
 }
 
-func percentToProbability(value float64) float64 {
-	if value < 0 {
-		value = 0
-	}
-	if value > 100 {
-		value = 100
-	}
-	return value / 100
-}
-
 // Step advances the simulation by applying the vegetation succession rules once.
 func (w *World) Step() {
 	if w.w == 0 || w.h == 0 {
 		return
 	}
 
-	w.windPhase++
-
-	w.updateRainMask()
-	w.updateVolcanoMask()
-	w.applyUplift()
-	w.applyEruptions()
-	w.applyLava()
-	w.applyFire()
-
-	grassNeighbors, shrubNeighbors := w.mooreNeighborCounts()
-
-	thresholdGrass := uint8(w.cfg.Params.GrassNeighborThreshold)
-	thresholdShrub := uint8(w.cfg.Params.ShrubNeighborThreshold)
-	thresholdTree := uint8(w.cfg.Params.TreeNeighborThreshold)
-
-	total := w.w * w.h
-	for i := 0; i < total; i++ {
-		current := w.vegCurr[i]
-		next := current
+	prof := &w.profile
+	prof.startTick()
+	params := w.cfg.Params
+	w.quiesceDisabled()
 
-		if w.burnTTL[i] > 0 {
-			w.vegNext[i] = next
-			continue
-		}
+	if params.Wind {
+		w.windPhase++
+	}
+	w.climateTick++
+	w.updateClimate()
+	prof.lap(phaseClimate)
 
-		switch current {
-		case VegetationNone:
-			if w.groundCurr[i] == GroundDirt && grassNeighbors[i] >= thresholdGrass {
-				if w.rng.Float64() < w.cfg.Params.GrassSpreadChance {
-					next = VegetationGrass
-				}
-			}
-		case VegetationGrass:
-			if grassNeighbors[i] >= thresholdShrub {
-				if w.rng.Float64() < w.cfg.Params.ShrubGrowthChance {
-					next = VegetationShrub
-				}
-			}
-		case VegetationShrub:
-			if shrubNeighbors[i] >= thresholdTree {
-				if w.rng.Float64() < w.cfg.Params.TreeGrowthChance {
-					next = VegetationTree
-				}
-			}
-		}
+	if params.Rain {
+		w.updateRainMask()
+	}
+	prof.lap(phaseRain)
+	if params.Volcanoes {
+		w.updateVolcanoMask()
+		prof.lap(phaseVolcano)
+		w.applyUplift()
+		prof.lap(phaseUplift)
+		w.applyEruptions()
+		prof.lap(phaseEruptions)
+	}
+	if params.Lava {
+		w.applyLava()
+	}
+	prof.lap(phaseLava)
+	if params.Hydrology {
+		w.applyHydrology()
+	}
+	prof.lap(phaseHydrology)
+	if params.Erosion {
+		w.applyErosion()
+	} else {
+		w.erosionEvents = erosionEvents{}
+	}
+	prof.lap(phaseErosion)
+	if params.Fire {
+		w.applyLightning()
+		prof.lap(phaseLightning)
+		w.applyFire()
+	}
+	prof.lap(phaseFire)
+	if params.Agents {
+		w.applyAgents()
+	}
+	prof.lap(phaseAgents)
 
-		w.vegNext[i] = next
+	if params.Succession {
+		w.applyVegetation()
+	} else {
+		copy(w.vegNext, w.vegCurr)
+		w.vegEvents = vegetationEvents{}
 	}
+	prof.lap(phaseSuccession)
 
 	w.updateMetrics(w.vegNext)
 	w.vegCurr, w.vegNext = w.vegNext, w.vegCurr
+	prof.lap(phaseMetrics)
+
+	if params.Soil {
+		w.updateSoilMoisture()
+	}
+	prof.lap(phaseSoil)
 
-	w.spawnRainRegion()
-	w.spawnVolcanoProtoRegion()
+	if params.Rain {
+		if params.Weather {
+			w.updateWeather()
+		} else {
+			w.spawnRainRegion()
+		}
+	}
+	if params.Volcanoes {
+		w.spawnVolcanoProtoRegion()
+	}
+	prof.lap(phaseSpawning)
 
 	w.rebuildDisplay()
+	prof.lap(phaseDisplay)
 }
 
 func midpointInt(min, max int) int {
@@ -1135,7 +1069,7 @@ func (w *World) updateRainMask() {
 		merged := make([]bool, len(w.rainRegions))
 		for i := 0; i < len(w.rainRegions); i++ {
 			for j := i + 1; j < len(w.rainRegions); j++ {
-				overlap := rainOverlapRatio(w.rainRegions[i], w.rainRegions[j])
+				overlap := w.rainOverlapRatio(w.rainRegions[i], w.rainRegions[j])
 				if overlap <= 0.15 {
 					continue
 				}
@@ -1195,6 +1129,7 @@ func (w *World) updateRainMask() {
 	}
 
 	w.rainRegions = nextRegions
+	w.addOrographicRain()
 	w.applyRainMorphology()
 
 	w.rainCurr, w.rainNext = w.rainNext, w.rainCurr
@@ -1214,18 +1149,8 @@ func (w *World) rasterizeRainRegion(region *rainRegion) {
 	minY := int(math.Floor(region.cy - region.radiusY - padding))
 	maxY := int(math.Ceil(region.cy + region.radiusY + padding))
 
-	if minX < 0 {
-		minX = 0
-	}
-	if minY < 0 {
-		minY = 0
-	}
-	if maxX >= w.w {
-		maxX = w.w - 1
-	}
-	if maxY >= w.h {
-		maxY = w.h - 1
-	}
+	minX, maxX = w.tileSpan(minX, maxX, w.w)
+	minY, maxY = w.tileSpan(minY, maxY, w.h)
 
 	if minX > maxX || minY > maxY {
 		return
@@ -1265,12 +1190,6 @@ func (w *World) rasterizeRainRegion(region *rainRegion) {
 			rx := dx*cosA + dy*sinA
 			ry := -dx*sinA + dy*cosA
 
-			nx := (rx * stretchX) * noiseScale
-			ny := (ry * stretchY) * noiseScale
-			nx += region.noiseOffsetX
-			ny += region.noiseOffsetY
-			n := fbmNoise2D(nx, ny, 3, 0.5, 1.9, region.noiseSeed)
-
 			distX := rx * invRadiusX
 			distY := ry * invRadiusY
 			radial := math.Sqrt(distX*distX + distY*distY)
@@ -1278,6 +1197,12 @@ func (w *World) rasterizeRainRegion(region *rainRegion) {
 				continue
 			}
 
+			nx := (rx * stretchX) * noiseScale
+			ny := (ry * stretchY) * noiseScale
+			nx += region.noiseOffsetX
+			ny += region.noiseOffsetY
+			n := fbmNoise2D(nx, ny, 3, 0.5, 1.9, region.noiseSeed)
+
 			// SOFT CLOUD MASK
 			c := smoothstep(threshold-0.1, threshold+0.1, n)
 			if c <= 0 {
@@ -1296,8 +1221,8 @@ func (w *World) rasterizeRainRegion(region *rainRegion) {
 				continue
 			}
 
-			idx := y*w.w + x
-			if idx < 0 || idx >= len(w.rainNext) {
+			idx, ok := w.tileIndex(x, y)
+			if !ok || idx >= len(w.rainNext) {
 				continue
 			}
 			if val <= w.rainNext[idx] {
@@ -1323,7 +1248,7 @@ func (w *World) spawnRainRegion() {
 		return
 	}
 
-	spawnChance := clampFloat(w.cfg.Params.RainSpawnChance, 0, 1)
+	spawnChance := clampFloat(w.cfg.Params.RainSpawnChance*w.climate.Rain, 0, 1)
 	if spawnChance <= 0 {
 		return
 	}
@@ -1343,11 +1268,11 @@ func (w *World) spawnRainRegion() {
 	for i := 0; i < attempts; i++ {
 		if hasActive && coverage > 0.15 {
 			skipChance := clampFloat((coverage-0.15)*3, 0, 0.9)
-			if w.rng.Float64() < skipChance {
+			if w.streams.rain.Float64() < skipChance {
 				continue
 			}
 		}
-		if w.rng.Float64() >= spawnChance {
+		if w.streams.rain.Float64() >= spawnChance {
 			continue
 		}
 		region := w.makeRainRegion()
@@ -1411,7 +1336,7 @@ func (w *World) advanceRainRegion(regions []rainRegion, index int) rainRegion {
 		if neighbor.ttl <= 0 {
 			continue
 		}
-		if math.Hypot(neighbor.cx-region.cx, neighbor.cy-region.cy) > cohesionRadius {
+		if math.Hypot(w.wrapDelta(neighbor.cx-region.cx, w.w), w.wrapDelta(neighbor.cy-region.cy, w.h)) > cohesionRadius {
 			continue
 		}
 		sumVX += neighbor.vx
@@ -1443,6 +1368,11 @@ func (w *World) advanceRainRegion(regions []rainRegion, index int) rainRegion {
 	region.cx += dx
 	region.cy += dy
 
+	if w.torus {
+		// Regions drifting off one edge re-enter on the opposite one.
+		region.cx = w.wrapPosition(region.cx, w.w)
+		region.cy = w.wrapPosition(region.cy, w.h)
+	}
 	marginX := region.radiusX
 	if marginX < 1 {
 		marginX = 1
@@ -1505,6 +1435,7 @@ func (w *World) applyRainMorphology() {
 	const closingRadius = 3
 	const openingRadius = 1
 
+	w.boundRainRows(w.rainNext)
 	w.dilateRain(w.rainNext, w.rainScratch, closingRadius)
 	w.erodeRain(w.rainScratch, w.rainNext, closingRadius, 0.02)
 
@@ -1514,122 +1445,223 @@ func (w *World) applyRainMorphology() {
 	w.removeTinyRainIslands(25, 0.05)
 }
 
+// dilateRain sets each tile of dst to the largest value of src in the
+// (2·radius+1)² square around it.
 func (w *World) dilateRain(src, dst []float32, radius int) {
-	if radius <= 0 {
-		copy(dst, src)
+	w.rainMorphology(src, dst, radius, true)
+}
+
+// erodeRain sets each tile of dst to the smallest value of src in the
+// (2·radius+1)² square around it, zeroing results below floor.
+func (w *World) erodeRain(src, dst []float32, radius int, floor float32) {
+	w.rainMorphology(src, dst, radius, false)
+	if floor <= 0 {
 		return
 	}
-	for y := 0; y < w.h; y++ {
-		for x := 0; x < w.w; x++ {
-			maxVal := float32(0)
-			for oy := -radius; oy <= radius; oy++ {
-				ny := y + oy
-				if ny < 0 || ny >= w.h {
-					continue
-				}
-				for ox := -radius; ox <= radius; ox++ {
-					nx := x + ox
-					if nx < 0 || nx >= w.w {
-						continue
-					}
-					v := src[ny*w.w+nx]
-					if v > maxVal {
-						maxVal = v
-					}
-				}
+	for y, span := range w.scratch.rainBounds {
+		for x := span.lo; x <= span.hi; x++ {
+			if i := y*w.w + x; dst[i] < floor {
+				dst[i] = 0
 			}
-			dst[y*w.w+x] = maxVal
 		}
 	}
 }
 
-func (w *World) erodeRain(src, dst []float32, radius int, floor float32) {
+// boundRainRows records the span of each row of mask that holds rain, so
+// the morphology passes can skip the dry parts of the map.
+func (w *World) boundRainRows(mask []float32) {
+	bounds := resize(w.scratch.rainBounds, w.h)
+	w.scratch.rainBounds = bounds
+	for y := range bounds {
+		row := mask[y*w.w : (y+1)*w.w]
+		span := rowSpan{lo: w.w, hi: -1}
+		for x, v := range row {
+			if v != 0 {
+				span.lo = x
+				break
+			}
+		}
+		for x := w.w - 1; x >= span.lo; x-- {
+			if row[x] != 0 {
+				span.hi = x
+				break
+			}
+		}
+		if span.hi < span.lo {
+			span = rowSpan{lo: 0, hi: -1}
+		}
+		bounds[y] = span
+	}
+}
+
+// rainMorphology applies a square max (dilate) or min (erode) filter. The
+// square is separable, so it runs as a row pass into scratch and a column
+// pass into dst. Rain is never negative, so each pass only visits the span
+// of a row that can be non-zero: a dilation spreads the span by radius, an
+// erosion keeps within it, and the column pass takes the union (dilate) or
+// intersection (erode) of the row spans in its window. The spans of src come
+// from rainBounds, which is left holding the spans of dst.
+func (w *World) rainMorphology(src, dst []float32, radius int, dilate bool) {
 	if radius <= 0 {
 		copy(dst, src)
 		return
 	}
+	total := w.w * w.h
+	pass := resize(w.scratch.rainPass, total)
+	spans := resize(w.scratch.rainSpans, w.h)
+	bounds := w.scratch.rainBounds
+	w.scratch.rainPass, w.scratch.rainSpans = pass, spans
+
 	for y := 0; y < w.h; y++ {
-		for x := 0; x < w.w; x++ {
-			minVal := float32(1)
-			hasVal := false
-			for oy := -radius; oy <= radius; oy++ {
-				ny := y + oy
-				if ny < 0 || ny >= w.h {
-					continue
-				}
-				for ox := -radius; ox <= radius; ox++ {
-					nx := x + ox
-					if nx < 0 || nx >= w.w {
-						continue
+		row := src[y*w.w : (y+1)*w.w]
+		out := pass[y*w.w : (y+1)*w.w]
+		clear(out)
+		span := bounds[y]
+		if span.hi < span.lo {
+			spans[y] = span
+			continue
+		}
+		if dilate {
+			span.lo -= radius
+			span.hi += radius
+		}
+		if span.lo < 0 || span.hi >= w.w {
+			if w.torus && dilate {
+				span = rowSpan{lo: 0, hi: w.w - 1}
+			}
+			span.lo, span.hi = max(span.lo, 0), min(span.hi, w.w-1)
+		}
+		spans[y] = span
+		for x := span.lo; x <= span.hi; x++ {
+			acc := row[x]
+			if x >= radius && x+radius < w.w {
+				window := row[x-radius : x+radius+1]
+				if dilate {
+					for _, v := range window {
+						if v > acc {
+							acc = v
+						}
 					}
-					v := src[ny*w.w+nx]
-					if !hasVal || v < minVal {
-						minVal = v
-						hasVal = true
+				} else {
+					for _, v := range window {
+						if v < acc {
+							acc = v
+						}
 					}
 				}
+				out[x] = acc
+				continue
+			}
+			for ox := -radius; ox <= radius; ox++ {
+				nx, ok := w.wrapAxis(x+ox, w.w)
+				if !ok {
+					continue
+				}
+				if v := row[nx]; (dilate && v > acc) || (!dilate && v < acc) {
+					acc = v
+				}
 			}
-			if !hasVal {
-				minVal = 0
+			out[x] = acc
+		}
+	}
+
+	for y := 0; y < w.h; y++ {
+		out := dst[y*w.w : (y+1)*w.w]
+		clear(out)
+		span := spans[y]
+		for oy := -radius; oy <= radius; oy++ {
+			ny, ok := w.wrapAxis(y+oy, w.h)
+			if !ok {
+				continue
+			}
+			other := spans[ny]
+			if dilate && other.hi >= other.lo {
+				if span.hi < span.lo {
+					span = other
+				}
+				span.lo, span.hi = min(span.lo, other.lo), max(span.hi, other.hi)
+			} else if !dilate {
+				span.lo, span.hi = max(span.lo, other.lo), min(span.hi, other.hi)
+			}
+		}
+		if span.hi < span.lo {
+			bounds[y] = rowSpan{lo: 0, hi: -1}
+			continue
+		}
+		bounds[y] = span
+		copy(out[span.lo:span.hi+1], pass[y*w.w+span.lo:y*w.w+span.hi+1])
+		for oy := -radius; oy <= radius; oy++ {
+			ny, ok := w.wrapAxis(y+oy, w.h)
+			if !ok || oy == 0 {
+				continue
 			}
-			if minVal < floor {
-				minVal = 0
+			other := pass[ny*w.w+span.lo : ny*w.w+span.hi+1]
+			target := out[span.lo : span.hi+1]
+			if dilate {
+				for x, v := range other {
+					if v > target[x] {
+						target[x] = v
+					}
+				}
+			} else {
+				for x, v := range other {
+					if v < target[x] {
+						target[x] = v
+					}
+				}
 			}
-			dst[y*w.w+x] = minVal
 		}
 	}
 }
 
+// removeTinyRainIslands zeroes Moore-connected patches of rain above
+// threshold that cover fewer than minArea tiles. Patches can only start
+// inside the row spans the morphology left in rainBounds.
 func (w *World) removeTinyRainIslands(minArea int, threshold float32) {
 	total := w.w * w.h
-	if total == 0 || len(w.rainNext) != total {
+	if total == 0 || len(w.rainNext) != total || len(w.scratch.rainBounds) != w.h {
 		return
 	}
-	visited := make([]bool, total)
-	component := make([]int, 0, minArea)
-	queue := make([]int, 0, minArea)
+	visited := w.clearedVisited()
+	component := w.scratch.component[:0]
+	queue := w.scratch.stack[:0]
 	neighbors := [8][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}, {-1, -1}, {1, -1}, {-1, 1}, {1, 1}}
 
-	for idx := 0; idx < total; idx++ {
-		if visited[idx] || w.rainNext[idx] <= threshold {
-			continue
-		}
-		visited[idx] = true
-		component = component[:0]
-		component = append(component, idx)
-		queue = queue[:0]
-		queue = append(queue, idx)
-		area := 0
-		for len(queue) > 0 {
-			cur := queue[len(queue)-1]
-			queue = queue[:len(queue)-1]
-			area++
-			cx := cur % w.w
-			cy := cur / w.w
-			for _, n := range neighbors {
-				nx := cx + n[0]
-				ny := cy + n[1]
-				if nx < 0 || nx >= w.w || ny < 0 || ny >= w.h {
-					continue
-				}
-				nIdx := ny*w.w + nx
-				if visited[nIdx] {
-					continue
-				}
-				if w.rainNext[nIdx] <= threshold {
-					continue
+	for y, span := range w.scratch.rainBounds {
+		for idx := y*w.w + span.lo; idx <= y*w.w+span.hi; idx++ {
+			if visited[idx] || w.rainNext[idx] <= threshold {
+				continue
+			}
+			visited[idx] = true
+			component = component[:0]
+			component = append(component, idx)
+			queue = queue[:0]
+			queue = append(queue, idx)
+			area := 0
+			for len(queue) > 0 {
+				cur := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				area++
+				cx := cur % w.w
+				cy := cur / w.w
+				for _, n := range neighbors {
+					nIdx, ok := w.tileIndex(cx+n[0], cy+n[1])
+					if !ok || visited[nIdx] || w.rainNext[nIdx] <= threshold {
+						continue
+					}
+					visited[nIdx] = true
+					queue = append(queue, nIdx)
+					component = append(component, nIdx)
 				}
-				visited[nIdx] = true
-				queue = append(queue, nIdx)
-				component = append(component, nIdx)
 			}
-		}
-		if area < minArea {
-			for _, cIdx := range component {
-				w.rainNext[cIdx] = 0
+			if area < minArea {
+				for _, cIdx := range component {
+					w.rainNext[cIdx] = 0
+				}
 			}
 		}
 	}
+	w.scratch.component, w.scratch.stack = component, queue
 }
 
 func (w *World) currentRainCoverageRatio() float64 {
@@ -1663,7 +1695,7 @@ func (w *World) makeRainRegion() rainRegion {
 
 	baseRadius := float64(radiusMin)
 	if radiusMax > radiusMin {
-		baseRadius = float64(radiusMin + w.rng.Intn(radiusMax-radiusMin+1))
+		baseRadius = float64(radiusMin + w.streams.rain.Intn(radiusMax-radiusMin+1))
 	}
 
 	ttlMin := params.RainTTLMin
@@ -1686,46 +1718,46 @@ func (w *World) makeRainRegion() rainRegion {
 
 	baseStrength := strengthMin
 	if strengthMax > strengthMin {
-		baseStrength += w.rng.Float64() * (strengthMax - strengthMin)
+		baseStrength += w.streams.rain.Float64() * (strengthMax - strengthMin)
 	}
 
-	threshold := 0.42 + w.rng.Float64()*0.1
-	falloff := 1.15 + w.rng.Float64()*0.05
-	noiseScale := 0.075 + w.rng.Float64()*0.01
+	threshold := 0.42 + w.streams.rain.Float64()*0.1
+	falloff := 1.15 + w.streams.rain.Float64()*0.05
+	noiseScale := 0.075 + w.streams.rain.Float64()*0.01
 	stretchX := 1.0
 	stretchY := 1.0
 	radiusX := baseRadius
 	radiusY := baseRadius
 	ttl := ttlMin
 	if ttlMax > ttlMin {
-		ttl += w.rng.Intn(ttlMax - ttlMin + 1)
+		ttl += w.streams.rain.Intn(ttlMax - ttlMin + 1)
 	}
 
-	presetRoll := w.rng.Float64()
+	presetRoll := w.streams.rain.Float64()
 	preset := rainPresetPuffy
 	switch {
 	case presetRoll < 0.55:
 		preset = rainPresetPuffy
-		falloff = 1.12 + w.rng.Float64()*0.08
+		falloff = 1.12 + w.streams.rain.Float64()*0.08
 	case presetRoll < 0.85:
 		preset = rainPresetStratus
-		radiusX = baseRadius * (1.1 + w.rng.Float64()*0.4)
+		radiusX = baseRadius * (1.1 + w.streams.rain.Float64()*0.4)
 		radiusY = baseRadius * 0.6
 		stretchY = 0.6
-		noiseScale = 0.06 + w.rng.Float64()*0.015
-		falloff = 1.08 + w.rng.Float64()*0.08
+		noiseScale = 0.06 + w.streams.rain.Float64()*0.015
+		falloff = 1.08 + w.streams.rain.Float64()*0.08
 	default:
 		preset = rainPresetSquall
-		radiusX = 40 + w.rng.Float64()*20
-		radiusY = 10 + w.rng.Float64()*6
+		radiusX = 40 + w.streams.rain.Float64()*20
+		radiusY = 10 + w.streams.rain.Float64()*6
 		if radiusX > float64(params.RainRadiusMax)*1.5 {
 			radiusX = float64(params.RainRadiusMax) * 1.5
 		}
 		stretchX = 1.2
 		stretchY = 0.8
-		noiseScale = 0.065 + w.rng.Float64()*0.02
-		falloff = 1.05 + w.rng.Float64()*0.08
-		ttl = 8 + w.rng.Intn(8)
+		noiseScale = 0.065 + w.streams.rain.Float64()*0.02
+		falloff = 1.05 + w.streams.rain.Float64()*0.08
+		ttl = 8 + w.streams.rain.Intn(8)
 	}
 
 	if radiusX < 10 {
@@ -1744,14 +1776,14 @@ func (w *World) makeRainRegion() rainRegion {
 		radiusY = maxSpanY
 	}
 
-	cx := float64(w.rng.Intn(w.w)) + 0.5
-	cy := float64(w.rng.Intn(w.h)) + 0.5
-	seed := w.rng.Int63()
+	cx := float64(w.streams.rain.Intn(w.w)) + 0.5
+	cy := float64(w.streams.rain.Intn(w.h)) + 0.5
+	seed := w.streams.rain.Int63()
 	vx, vy := w.windVector(cx, cy)
 
-	strengthVariation := 0.1 + w.rng.Float64()*0.1
-	noiseOffsetX := (w.rng.Float64()*2 - 1) * 5
-	noiseOffsetY := (w.rng.Float64()*2 - 1) * 5
+	strengthVariation := 0.1 + w.streams.rain.Float64()*0.1
+	noiseOffsetX := (w.streams.rain.Float64()*2 - 1) * 5
+	noiseOffsetY := (w.streams.rain.Float64()*2 - 1) * 5
 
 	region := rainRegion{
 		cx:                 cx,
@@ -1796,7 +1828,7 @@ const (
 func (w *World) windVector(x, y float64) (float64, float64) {
 	scale := w.cfg.Params.WindNoiseScale
 	speed := w.cfg.Params.WindSpeedScale
-	if scale <= 0 || speed <= 0 {
+	if !w.cfg.Params.Wind || scale <= 0 || speed <= 0 {
 		return 0, 0
 	}
 
@@ -1818,6 +1850,7 @@ func (w *World) windVector(x, y float64) (float64, float64) {
 		return 0, 0
 	}
 
+	speed *= w.climate.Wind
 	invMag := 1.0 / magnitude
 	vx := curlX * invMag * speed
 	vy := curlY * invMag * speed
@@ -1825,8 +1858,13 @@ func (w *World) windVector(x, y float64) (float64, float64) {
 	return vx, vy
 }
 
+// windPotentialAt samples the stream function the wind curls around. On a
+// torus the periodic blend matches the potential and its gradient across the
+// edges, so the wind field is seamless.
 func (w *World) windPotentialAt(x, y, scale, phase float64) float64 {
-	return fbmNoise3D(x*scale, y*scale, phase, 4, 0.5, 1.9, w.cfg.Seed)
+	return w.periodicNoise(x, y, func(x, y float64) float64 {
+		return fbmNoise3D(x*scale, y*scale, phase, 4, 0.5, 1.9, w.cfg.Seed)
+	})
 }
 
 func fbmNoise3D(x, y, z float64, octaves int, gain, lacunarity float64, seed int64) float64 {
@@ -2019,13 +2057,13 @@ func clamp01(value float64) float64 {
 	return value
 }
 
-func rainOverlapRatio(a, b rainRegion) float64 {
+func (w *World) rainOverlapRatio(a, b rainRegion) float64 {
 	ra := math.Sqrt(a.radiusX * a.radiusY)
 	rb := math.Sqrt(b.radiusX * b.radiusY)
 	if ra <= 0 || rb <= 0 {
 		return 0
 	}
-	d := math.Hypot(a.cx-b.cx, a.cy-b.cy)
+	d := math.Hypot(w.wrapDelta(a.cx-b.cx, w.w), w.wrapDelta(a.cy-b.cy, w.h))
 	areaA := math.Pi * ra * ra
 	areaB := math.Pi * rb * rb
 	intersection := circleIntersectionArea(ra, rb, d)
@@ -2107,18 +2145,8 @@ func (w *World) rasterizeVolcanoRegion(region volcanoProtoRegion) {
 	minY := int(math.Floor(region.cy - region.radius))
 	maxY := int(math.Ceil(region.cy + region.radius))
 
-	if minX < 0 {
-		minX = 0
-	}
-	if minY < 0 {
-		minY = 0
-	}
-	if maxX >= w.w {
-		maxX = w.w - 1
-	}
-	if maxY >= w.h {
-		maxY = w.h - 1
-	}
+	minX, maxX = w.tileSpan(minX, maxX, w.w)
+	minY, maxY = w.tileSpan(minY, maxY, w.h)
 
 	radius := region.radius
 	invRadius := 1.0 / radius
@@ -2140,8 +2168,8 @@ func (w *World) rasterizeVolcanoRegion(region volcanoProtoRegion) {
 			if value > 1 {
 				value = 1
 			}
-			idx := y*w.w + x
-			if idx < 0 || idx >= len(w.volNext) {
+			idx, ok := w.tileIndex(x, y)
+			if !ok || idx >= len(w.volNext) {
 				continue
 			}
 			if current := w.volNext[idx]; current >= float32(value) {
@@ -2158,13 +2186,9 @@ func (w *World) applyUplift() {
 		return
 	}
 
-	for i := 0; i < total; i++ {
-		w.groundNext[i] = w.groundCurr[i]
-	}
-
+	// Each tile only reads its own ground, so uplift writes in place.
 	baseChance := w.cfg.Params.VolcanoUpliftChanceBase
 	if baseChance <= 0 {
-		w.groundCurr, w.groundNext = w.groundNext, w.groundCurr
 		return
 	}
 
@@ -2186,16 +2210,21 @@ func (w *World) applyUplift() {
 		if chance <= 0 {
 			continue
 		}
-		if w.rng.Float64() >= chance {
+		if w.streams.volcano.Float64() >= chance {
 			continue
 		}
+		w.groundCurr[i] = GroundMountain
 		w.groundNext[i] = GroundMountain
 		if i < len(w.display) {
 			w.display[i] = uint8(GroundMountain)
 		}
+		if i < len(w.terrainElevation) && w.terrainElevation[i] < math.MaxInt16 {
+			w.terrainElevation[i]++
+			if w.lavaElevation[i] < w.terrainElevation[i] {
+				w.lavaElevation[i] = w.terrainElevation[i]
+			}
+		}
 	}
-
-	w.groundCurr, w.groundNext = w.groundNext, w.groundCurr
 }
 
 func (w *World) applyEruptions() {
@@ -2235,7 +2264,7 @@ func (w *World) applyEruptions() {
 		if chance > 1 {
 			chance = 1
 		}
-		if w.rng.Float64() >= chance {
+		if w.streams.volcano.Float64() >= chance {
 			continue
 		}
 
@@ -2259,18 +2288,8 @@ func (w *World) volcanoMaskMean(region volcanoProtoRegion) float64 {
 	minY := int(math.Floor(region.cy - region.radius))
 	maxY := int(math.Ceil(region.cy + region.radius))
 
-	if minX < 0 {
-		minX = 0
-	}
-	if minY < 0 {
-		minY = 0
-	}
-	if maxX >= w.w {
-		maxX = w.w - 1
-	}
-	if maxY >= w.h {
-		maxY = w.h - 1
-	}
+	minX, maxX = w.tileSpan(minX, maxX, w.w)
+	minY, maxY = w.tileSpan(minY, maxY, w.h)
 
 	var sum float64
 	var count int
@@ -2286,8 +2305,8 @@ func (w *World) volcanoMaskMean(region volcanoProtoRegion) float64 {
 			if dist > radius {
 				continue
 			}
-			idx := y*w.w + x
-			if idx < 0 || idx >= len(w.volCurr) {
+			idx, ok := w.tileIndex(x, y)
+			if !ok || idx >= len(w.volCurr) {
 				continue
 			}
 			sum += float64(w.volCurr[idx])
@@ -2318,18 +2337,8 @@ func (w *World) eruptRegion(region volcanoProtoRegion) {
 	minY := int(math.Floor(region.cy - region.radius))
 	maxY := int(math.Ceil(region.cy + region.radius))
 
-	if minX < 0 {
-		minX = 0
-	}
-	if minY < 0 {
-		minY = 0
-	}
-	if maxX >= w.w {
-		maxX = w.w - 1
-	}
-	if maxY >= w.h {
-		maxY = w.h - 1
-	}
+	minX, maxX = w.tileSpan(minX, maxX, w.w)
+	minY, maxY = w.tileSpan(minY, maxY, w.h)
 
 	coreRadius := region.radius * 0.35
 	rimRadius := region.radius * 0.9
@@ -2349,14 +2358,14 @@ func (w *World) eruptRegion(region volcanoProtoRegion) {
 			if dist > region.radius {
 				continue
 			}
-			idx := y*w.w + x
-			if idx < 0 || idx >= len(w.groundCurr) {
+			idx, ok := w.tileIndex(x, y)
+			if !ok || idx >= len(w.groundCurr) {
 				continue
 			}
 
 			switch {
 			case dist <= coreRadius:
-				height := 2 + w.rng.Intn(2)
+				height := 2 + w.streams.volcano.Intn(2)
 				w.setLavaCell(idx, height, 1, -1, false)
 				coreCells = append(coreCells, idx)
 			case dist <= rimRadius:
@@ -2373,27 +2382,16 @@ func (w *World) eruptRegion(region volcanoProtoRegion) {
 	if len(coreCells) == 0 {
 		cx := int(math.Round(region.cx))
 		cy := int(math.Round(region.cy))
-		if cx < 0 {
-			cx = 0
-		}
-		if cy < 0 {
-			cy = 0
+		if !w.torus {
+			cx = min(max(cx, 0), w.w-1)
+			cy = min(max(cy, 0), w.h-1)
 		}
-		if cx >= w.w {
-			cx = w.w - 1
-		}
-		if cy >= w.h {
-			cy = w.h - 1
-		}
-		if cx >= 0 && cy >= 0 {
-			centerIdx := cy*w.w + cx
-			if centerIdx >= 0 && centerIdx < len(w.groundCurr) {
-				coreCells = append(coreCells, centerIdx)
-			}
+		if centerIdx, ok := w.tileIndex(cx, cy); ok && centerIdx < len(w.groundCurr) {
+			coreCells = append(coreCells, centerIdx)
 		}
 	}
 
-	vents := 1 + w.rng.Intn(3)
+	vents := 1 + w.streams.volcano.Intn(3)
 	if vents > len(coreCells) {
 		vents = len(coreCells)
 	}
@@ -2401,7 +2399,7 @@ func (w *World) eruptRegion(region volcanoProtoRegion) {
 		return
 	}
 
-	w.rng.Shuffle(len(coreCells), func(i, j int) {
+	w.streams.volcano.Shuffle(len(coreCells), func(i, j int) {
 		coreCells[i], coreCells[j] = coreCells[j], coreCells[i]
 	})
 
@@ -2422,7 +2420,7 @@ func (w *World) eruptRegion(region volcanoProtoRegion) {
 		idx := coreCells[i]
 		mass := float64(reservoirMin)
 		if reservoirMax > reservoirMin {
-			mass += float64(w.rng.Intn(reservoirMax - reservoirMin + 1))
+			mass += float64(w.streams.volcano.Intn(reservoirMax - reservoirMin + 1))
 		}
 		outIdx, dir, downhill := w.pickDownhill(idx)
 		if !downhill {
@@ -2466,7 +2464,7 @@ func (w *World) spawnVolcanoProtoRegion() {
 	if params.VolcanoProtoSpawnChance <= 0 {
 		return
 	}
-	if w.rng.Float64() >= params.VolcanoProtoSpawnChance {
+	if w.streams.volcano.Float64() >= params.VolcanoProtoSpawnChance {
 		return
 	}
 
@@ -2484,8 +2482,8 @@ func (w *World) spawnVolcanoProtoRegion() {
 	}
 
 	for i := 0; i < attempts; i++ {
-		idx := w.rng.Intn(total)
-		score := float64(w.tectonic[idx]) + w.rng.Float64()*0.05
+		idx := w.streams.volcano.Intn(total)
+		score := float64(w.tectonic[idx]) + w.streams.volcano.Float64()*0.05
 		if score > bestScore {
 			bestScore = score
 			bestIdx = idx
@@ -2511,7 +2509,7 @@ func (w *World) spawnVolcanoProtoRegion() {
 	}
 	radius := radiusMin
 	if radiusMax > radiusMin {
-		radius += w.rng.Intn(radiusMax - radiusMin + 1)
+		radius += w.streams.volcano.Intn(radiusMax - radiusMin + 1)
 	}
 
 	ttlMin := params.VolcanoProtoTTLMin
@@ -2524,7 +2522,7 @@ func (w *World) spawnVolcanoProtoRegion() {
 	}
 	ttl := ttlMin
 	if ttlMax > ttlMin {
-		ttl += w.rng.Intn(ttlMax - ttlMin + 1)
+		ttl += w.streams.volcano.Intn(ttlMax - ttlMin + 1)
 	}
 
 	strengthMin := params.VolcanoProtoStrengthMin
@@ -2537,14 +2535,14 @@ func (w *World) spawnVolcanoProtoRegion() {
 	}
 	strength := strengthMin
 	if strengthMax > strengthMin {
-		strength += w.rng.Float64() * (strengthMax - strengthMin)
+		strength += w.streams.volcano.Float64() * (strengthMax - strengthMin)
 	}
 	if strength > 1 {
 		strength = 1
 	}
 
 	jitter := func() float64 {
-		return w.rng.Float64() - 0.5
+		return w.streams.volcano.Float64() - 0.5
 	}
 
 	cx := float64(bestIdx%w.w) + 0.5 + jitter()
@@ -2568,7 +2566,7 @@ func (w *World) spawnVolcanoProtoRegion() {
 		radius:   float64(radius),
 		strength: strength,
 		ttl:      ttl,
-		noise:    w.rng.Int63(),
+		noise:    w.streams.volcano.Int63(),
 	})
 }
 
@@ -2599,6 +2597,13 @@ func (w *World) applyLava() {
 		return
 	}
 
+	if w.lavaSettled && len(w.lavaVents) == 0 && !slices.Contains(w.groundCurr, GroundLava) {
+		// Nothing can flow, cool, or pool; only the channels keep fading.
+		w.lavaAdvancedCells = w.lavaAdvancedCells[:0]
+		w.reinforceLavaChannels()
+		return
+	}
+
 	copy(w.groundNext, w.groundCurr)
 	copy(w.lavaHeightNext, w.lavaHeight)
 	copy(w.lavaTempNext, w.lavaTemp)
@@ -2622,7 +2627,7 @@ func (w *World) applyLava() {
 		}
 	}
 	if len(w.lavaTipQueue) > 1 {
-		w.rng.Shuffle(len(w.lavaTipQueue), func(i, j int) {
+		w.streams.lava.Shuffle(len(w.lavaTipQueue), func(i, j int) {
 			w.lavaTipQueue[i], w.lavaTipQueue[j] = w.lavaTipQueue[j], w.lavaTipQueue[i]
 		})
 	}
@@ -2645,6 +2650,7 @@ func (w *World) applyLava() {
 	w.lavaDir, w.lavaDirNext = w.lavaDirNext, w.lavaDir
 	w.lavaTip, w.lavaTipNext = w.lavaTipNext, w.lavaTip
 	w.lavaForce, w.lavaForceNext = w.lavaForceNext, w.lavaForce
+	w.lavaSettled = len(w.lavaVents) == 0 && !slices.Contains(w.groundCurr, GroundLava)
 }
 
 func (w *World) processLavaVents() {
@@ -2702,8 +2708,8 @@ func (w *World) processLavaVents() {
 
 		baseHeight := int(w.lavaHeightNext[idx])
 		newHeight := baseHeight + units
-		if newHeight > lavaMaxHeight {
-			newHeight = lavaMaxHeight
+		if maxHeight := w.lavaMaxHeight(); newHeight > maxHeight {
+			newHeight = maxHeight
 		}
 		added := newHeight - baseHeight
 		if added <= 0 {
@@ -2714,7 +2720,7 @@ func (w *World) processLavaVents() {
 		w.lavaHeightNext[idx] = uint8(newHeight)
 		w.lavaTempNext[idx] = 1
 		w.lavaDirNext[idx] = vent.dir
-		w.lavaForceNext[idx] = newHeight >= lavaOverflowHeight
+		w.lavaForceNext[idx] = newHeight >= w.cfg.Params.LavaOverflowHeight
 		w.groundNext[idx] = GroundLava
 		if idx < len(w.vegCurr) {
 			w.vegCurr[idx] = VegetationNone
@@ -2739,7 +2745,7 @@ func (w *World) processLavaVents() {
 			}
 			w.lavaTempNext[outIdx] = 1
 			w.lavaDirNext[outIdx] = vent.dir
-			w.lavaForceNext[outIdx] = int(w.lavaHeightNext[outIdx]) >= lavaOverflowHeight
+			w.lavaForceNext[outIdx] = int(w.lavaHeightNext[outIdx]) >= w.cfg.Params.LavaOverflowHeight
 			w.lavaTipNext[outIdx] = true
 			w.lavaAdvancedCells = append(w.lavaAdvancedCells, outIdx)
 			if outIdx < len(w.vegCurr) {
@@ -2772,8 +2778,7 @@ func (w *World) spawnLavaChild(cand lavaCandidate, temp float32) bool {
 	if w.groundCurr[nIdx] == GroundLava || w.groundNext[nIdx] == GroundLava {
 		return false
 	}
-	ground := w.groundCurr[nIdx]
-	if ground != GroundDirt && ground != GroundRock {
+	if !lavaCanEnter(w.groundCurr[nIdx]) {
 		return false
 	}
 	if temp < 0 {
@@ -2808,6 +2813,7 @@ func (w *World) advanceLavaTip(idx int) bool {
 	if idx < 0 || idx >= len(w.groundCurr) {
 		return false
 	}
+	params := &w.cfg.Params
 	if w.groundCurr[idx] != GroundLava {
 		return false
 	}
@@ -2821,7 +2827,7 @@ func (w *World) advanceLavaTip(idx int) bool {
 	}
 	temp := w.lavaTemp[idx]
 	forceAdvance := w.lavaForce[idx]
-	chance := lavaBaseSpeed * float64(temp) / (1 + lavaSpeedAlpha*float64(height))
+	chance := params.LavaBaseSpeed * float64(temp) / (1 + params.LavaSpeedAlpha*float64(height))
 	if forceAdvance {
 		chance = 1
 	}
@@ -2831,7 +2837,7 @@ func (w *World) advanceLavaTip(idx int) bool {
 	if chance > 1 {
 		chance = 1
 	}
-	if !forceAdvance && w.rng.Float64() >= chance {
+	if !forceAdvance && w.streams.lava.Float64() >= chance {
 		return false
 	}
 
@@ -2848,8 +2854,7 @@ func (w *World) advanceLavaTip(idx int) bool {
 		if w.groundCurr[nIdx] == GroundLava || w.groundNext[nIdx] == GroundLava {
 			return
 		}
-		ground := w.groundCurr[nIdx]
-		if ground != GroundDirt && ground != GroundRock {
+		if !lavaCanEnter(w.groundCurr[nIdx]) {
 			return
 		}
 		for i := 0; i < count; i++ {
@@ -2883,7 +2888,7 @@ func (w *World) advanceLavaTip(idx int) bool {
 				wall = float64(diff)
 			}
 		}
-		score := lavaSlopeWeight*slope + lavaAlignWeight*align + lavaChannelWeight*channel - lavaRainWeight*rain - lavaWallWeight*wall
+		score := params.LavaSlopeWeight*slope + params.LavaAlignWeight*align + params.LavaChannelWeight*channel - params.LavaRainWeight*rain - params.LavaWallWeight*wall
 		candidates[count] = lavaCandidate{idx: nIdx, dir: dirIdx, score: score}
 		count++
 	}
@@ -2891,16 +2896,16 @@ func (w *World) advanceLavaTip(idx int) bool {
 	forward := lavaDirections[dir]
 	nx := x + forward.dx
 	ny := y + forward.dy
-	if nx >= 0 && nx < w.w && ny >= 0 && ny < w.h {
-		addCandidate(ny*w.w+nx, dir)
+	if nIdx, ok := w.tileIndex(nx, ny); ok {
+		addCandidate(nIdx, dir)
 	}
 	leftDir := lavaLeft(dir)
 	if leftDir >= 0 {
 		dv := lavaDirections[leftDir]
 		nx := x + dv.dx
 		ny := y + dv.dy
-		if nx >= 0 && nx < w.w && ny >= 0 && ny < w.h {
-			addCandidate(ny*w.w+nx, leftDir)
+		if nIdx, ok := w.tileIndex(nx, ny); ok {
+			addCandidate(nIdx, leftDir)
 		}
 	}
 	rightDir := lavaRight(dir)
@@ -2908,18 +2913,18 @@ func (w *World) advanceLavaTip(idx int) bool {
 		dv := lavaDirections[rightDir]
 		nx := x + dv.dx
 		ny := y + dv.dy
-		if nx >= 0 && nx < w.w && ny >= 0 && ny < w.h {
-			addCandidate(ny*w.w+nx, rightDir)
+		if nIdx, ok := w.tileIndex(nx, ny); ok {
+			addCandidate(nIdx, rightDir)
 		}
 	}
 
 	for dirIdx, dv := range lavaDirections {
 		nx := x + dv.dx
 		ny := y + dv.dy
-		if nx < 0 || nx >= w.w || ny < 0 || ny >= w.h {
+		nIdx, ok := w.tileIndex(nx, ny)
+		if !ok {
 			continue
 		}
-		nIdx := ny*w.w + nx
 		if nIdx < 0 || nIdx >= len(w.lavaElevation) {
 			continue
 		}
@@ -2947,7 +2952,7 @@ func (w *World) advanceLavaTip(idx int) bool {
 	if best < 0 {
 		return false
 	}
-	if candidates[best].score < lavaFlowThreshold {
+	if candidates[best].score < params.LavaFlowThreshold {
 		if !(forceAdvance && candidates[best].score >= 0) {
 			return false
 		}
@@ -2970,11 +2975,11 @@ func (w *World) advanceLavaTip(idx int) bool {
 		removed = height - newHeight
 	}
 
-	threshold := lavaFlowThreshold - lavaSplitThresholdDrop
+	threshold := params.LavaFlowThreshold - params.LavaSplitThresholdDrop
 	if threshold < 0 {
 		threshold = 0
 	}
-	if height >= lavaSplitMinHeight && w.rng.Float64() < lavaSplitChance && second >= 0 && candidates[second].idx != candidates[best].idx && candidates[second].score >= threshold {
+	if height >= params.LavaSplitMinHeight && w.streams.lava.Float64() < params.LavaSplitChance && second >= 0 && candidates[second].idx != candidates[best].idx && candidates[second].score >= threshold {
 		if newHeight > 1 {
 			if w.spawnLavaChild(candidates[second], childTemp) {
 				removed++
@@ -2993,7 +2998,7 @@ func (w *World) advanceLavaTip(idx int) bool {
 	}
 
 	w.lavaHeightNext[idx] = uint8(newHeight)
-	w.lavaForceNext[idx] = newHeight >= lavaOverflowHeight
+	w.lavaForceNext[idx] = newHeight >= w.cfg.Params.LavaOverflowHeight
 
 	return true
 }
@@ -3017,15 +3022,14 @@ func (w *World) poolFailedTips() {
 		for _, dv := range lavaDirections {
 			nx := x + dv.dx
 			ny := y + dv.dy
-			if nx < 0 || nx >= w.w || ny < 0 || ny >= w.h {
+			nIdx, ok := w.tileIndex(nx, ny)
+			if !ok {
 				continue
 			}
-			nIdx := ny*w.w + nx
 			if w.groundCurr[nIdx] == GroundLava || w.groundNext[nIdx] == GroundLava {
 				continue
 			}
-			ground := w.groundCurr[nIdx]
-			if ground != GroundDirt && ground != GroundRock {
+			if !lavaCanEnter(w.groundCurr[nIdx]) {
 				continue
 			}
 			if nIdx < len(w.lavaElevation) && w.lavaElevation[nIdx] > elevHere {
@@ -3035,7 +3039,7 @@ func (w *World) poolFailedTips() {
 			optionCount++
 		}
 		if optionCount > 0 {
-			target := options[w.rng.Intn(optionCount)]
+			target := options[w.streams.lava.Intn(optionCount)]
 			temp := w.lavaTemp[idx] - 0.1
 			if temp < 0 {
 				temp = 0
@@ -3061,11 +3065,11 @@ func (w *World) poolFailedTips() {
 		}
 
 		newHeight := int(w.lavaHeight[idx]) + 1
-		if newHeight > lavaMaxHeight {
-			newHeight = lavaMaxHeight
+		if maxHeight := w.lavaMaxHeight(); newHeight > maxHeight {
+			newHeight = maxHeight
 		}
 		w.lavaHeightNext[idx] = uint8(newHeight)
-		w.lavaForceNext[idx] = newHeight >= lavaOverflowHeight
+		w.lavaForceNext[idx] = newHeight >= w.cfg.Params.LavaOverflowHeight
 	}
 }
 
@@ -3096,10 +3100,10 @@ func (w *World) coolLavaCells() {
 		for _, dv := range lavaDirections {
 			nx := x + dv.dx
 			ny := y + dv.dy
-			if nx < 0 || nx >= w.w || ny < 0 || ny >= w.h {
+			nIdx, ok := w.tileIndex(nx, ny)
+			if !ok {
 				continue
 			}
-			nIdx := ny*w.w + nx
 			if w.groundNext[nIdx] == GroundLava {
 				neighbors++
 			}
@@ -3113,7 +3117,7 @@ func (w *World) coolLavaCells() {
 		if idx < len(w.rainCurr) {
 			rain = float64(w.rainCurr[idx])
 		}
-		params := w.cfg.Params
+		params := &w.cfg.Params
 		baseCool := params.LavaCoolBase
 		rainCool := params.LavaCoolRain
 		edgeCool := params.LavaCoolEdge
@@ -3167,8 +3171,8 @@ func (w *World) coolLavaCells() {
 			hysteresis = 0
 		}
 		reheatCap := threshold + hysteresis
-		if reheatCap > lavaReheatCap {
-			reheatCap = lavaReheatCap
+		if reheatCap > params.LavaReheatCap {
+			reheatCap = params.LavaReheatCap
 		}
 		if reheatCap < 0 {
 			reheatCap = 0
@@ -3177,16 +3181,18 @@ func (w *World) coolLavaCells() {
 		if temp <= threshold {
 			if height > 1 {
 				height--
-				if height < 1 {
-					height = 1
-				}
+				w.solidifyLava(idx, 1)
 				w.lavaHeightNext[idx] = uint8(height)
 				if temp > reheatCap {
 					temp = reheatCap
 				}
 				w.lavaTempNext[idx] = float32(temp)
 			} else {
-				w.groundNext[idx] = GroundRock
+				w.groundNext[idx] = GroundBasalt
+				w.solidifyLava(idx, 1)
+				if idx < len(w.lavaScar) {
+					w.lavaScar[idx] = 1
+				}
 				w.lavaHeightNext[idx] = 0
 				w.lavaTempNext[idx] = 0
 				w.lavaDirNext[idx] = -1
@@ -3196,7 +3202,7 @@ func (w *World) coolLavaCells() {
 			}
 		}
 
-		w.lavaForceNext[idx] = height >= lavaOverflowHeight
+		w.lavaForceNext[idx] = height >= params.LavaOverflowHeight
 		if idx < len(w.lavaFluxOut) {
 			w.lavaFluxOut[idx] = 0
 		}
@@ -3208,14 +3214,14 @@ func (w *World) reinforceLavaChannels() {
 		if idx < 0 || idx >= len(w.lavaChannel) {
 			continue
 		}
-		value := w.lavaChannel[idx] + float32(lavaChannelGrow)
+		value := w.lavaChannel[idx] + float32(w.cfg.Params.LavaChannelGrow)
 		if value > 1 {
 			value = 1
 		}
 		w.lavaChannel[idx] = value
 	}
 
-	decay := float32(1 - lavaChannelDecay)
+	decay := float32(1 - w.cfg.Params.LavaChannelDecay)
 	for i := range w.lavaChannel {
 		w.lavaChannel[i] *= decay
 		if w.lavaChannel[i] < 0 {
@@ -3232,13 +3238,13 @@ func (w *World) detectLavaTips() {
 			w.lavaForceNext[idx] = false
 			continue
 		}
-		w.lavaForceNext[idx] = w.lavaHeightNext[idx] >= lavaOverflowHeight
+		w.lavaForceNext[idx] = int(w.lavaHeightNext[idx]) >= w.cfg.Params.LavaOverflowHeight
 		dir := w.lavaDirNext[idx]
 		if dir < 0 {
 			w.lavaTipNext[idx] = false
 			continue
 		}
-		if w.lavaTempNext[idx] <= float32(lavaTipTemperatureMin) {
+		if w.lavaTempNext[idx] <= float32(w.cfg.Params.LavaTipTemperatureMin) {
 			w.lavaTipNext[idx] = false
 			continue
 		}
@@ -3248,10 +3254,10 @@ func (w *World) detectLavaTips() {
 		for _, dv := range lavaDirections {
 			nx := x + dv.dx
 			ny := y + dv.dy
-			if nx < 0 || nx >= w.w || ny < 0 || ny >= w.h {
+			nIdx, ok := w.tileIndex(nx, ny)
+			if !ok {
 				continue
 			}
-			nIdx := ny*w.w + nx
 			if w.groundNext[nIdx] == GroundLava {
 				neighbors++
 			}
@@ -3284,6 +3290,8 @@ func (w *World) applyFire() {
 	if lavaIgnite < 0 {
 		lavaIgnite = 0
 	}
+	windBias := w.cfg.Params.FireWindBias
+	emberChance := w.cfg.Params.FireEmberChance
 
 	rainModifier := func(value float64) float64 {
 		if rainDampen <= 0 || value <= 0 {
@@ -3321,7 +3329,7 @@ func (w *World) applyFire() {
 				if chance > 1 {
 					chance = 1
 				}
-				if w.rng.Float64() < chance {
+				if w.streams.fire.Float64() < chance {
 					extinguished = true
 				}
 			}
@@ -3353,32 +3361,40 @@ func (w *World) applyFire() {
 				}
 			}
 
+			var windX, windY float64
+			if windBias > 0 || emberChance > 0 {
+				windX, windY = w.windVector(float64(x)+0.5, float64(y)+0.5)
+			}
+			if emberChance > 0 {
+				w.spotEmber(x, y, windX, windY, emberChance, baseTTL, rainModifier)
+			}
+
 			if spreadChance <= 0 {
 				continue
 			}
 
 			for dy := -1; dy <= 1; dy++ {
-				ny := y + dy
-				if ny < 0 || ny >= w.h {
+				ny, ok := w.wrapAxis(y+dy, w.h)
+				if !ok {
 					continue
 				}
 				for dx := -1; dx <= 1; dx++ {
-					nx := x + dx
-					if nx < 0 || nx >= w.w {
+					nx, ok := w.wrapAxis(x+dx, w.w)
+					if !ok {
 						continue
 					}
 					if dx == 0 && dy == 0 {
 						continue
 					}
 					nIdx := ny*w.w + nx
-					if w.vegCurr[nIdx] == VegetationNone {
+					if w.vegCurr[nIdx] == VegetationNone || w.groundCurr[nIdx] == GroundWater {
 						continue
 					}
 					if int(w.burnTTL[nIdx]) > 0 {
 						continue
 					}
 
-					chance := spreadChance
+					chance := spreadChance * fireWindModifier(dx, dy, windX, windY, windBias) * w.soilFireFactor(nIdx)
 					if nIdx < len(w.rainCurr) {
 						chance *= rainModifier(float64(w.rainCurr[nIdx]))
 					}
@@ -3388,7 +3404,7 @@ func (w *World) applyFire() {
 					if chance > 1 {
 						chance = 1
 					}
-					if w.rng.Float64() >= chance {
+					if w.streams.fire.Float64() >= chance {
 						continue
 					}
 
@@ -3426,13 +3442,13 @@ func (w *World) applyFire() {
 				}
 
 				for dy := -1; dy <= 1; dy++ {
-					ny := y + dy
-					if ny < 0 || ny >= w.h {
+					ny, ok := w.wrapAxis(y+dy, w.h)
+					if !ok {
 						continue
 					}
 					for dx := -1; dx <= 1; dx++ {
-						nx := x + dx
-						if nx < 0 || nx >= w.w {
+						nx, ok := w.wrapAxis(x+dx, w.w)
+						if !ok {
 							continue
 						}
 						if dx == 0 && dy == 0 {
@@ -3452,7 +3468,7 @@ func (w *World) applyFire() {
 							continue
 						}
 
-						chance := lavaIgnite
+						chance := lavaIgnite * w.soilFireFactor(nIdx)
 						if nIdx < len(w.rainCurr) {
 							chance *= rainModifier(float64(w.rainCurr[nIdx]))
 						}
@@ -3462,7 +3478,7 @@ func (w *World) applyFire() {
 						if chance > 1 {
 							chance = 1
 						}
-						if w.rng.Float64() >= chance {
+						if w.streams.fire.Float64() >= chance {
 							continue
 						}
 
@@ -3488,9 +3504,81 @@ func (w *World) applyFire() {
 	w.burnTTL, w.burnNext = w.burnNext, w.burnTTL
 }
 
-// IgniteAt manually starts a burn at the provided coordinates when vegetation is present.
+// fireWindModifier scales the spread chance toward the neighbour at (dx, dy)
+// by how well that direction lines up with the local wind: downwind neighbours
+// gain up to bias*speed, upwind ones lose the same amount, and crosswind
+// neighbours are unaffected. A zero bias keeps spread isotropic.
+func fireWindModifier(dx, dy int, windX, windY, bias float64) float64 {
+	if bias <= 0 || (windX == 0 && windY == 0) {
+		return 1
+	}
+	dist := math.Hypot(float64(dx), float64(dy))
+	if dist == 0 {
+		return 1
+	}
+	alignment := (float64(dx)*windX + float64(dy)*windY) / dist
+	modifier := 1 + bias*alignment
+	if modifier < 0 {
+		return 0
+	}
+	return modifier
+}
+
+// spotEmber lofts an ember from the burning tile at (x, y) and lands it
+// downwind. Stronger wind raises both the launch chance and the carry
+// distance, which ranges from two tiles up to FireEmberDistance; a small
+// lateral jitter keeps spot fires from lining up perfectly.
+func (w *World) spotEmber(x, y int, windX, windY, chance float64, ttl int, rainModifier func(float64) float64) {
+	speed := math.Hypot(windX, windY)
+	if speed <= 0 {
+		return
+	}
+	strength := speed
+	if strength > 1 {
+		strength = 1
+	}
+	if w.streams.fire.Float64() >= chance*strength {
+		return
+	}
+
+	maxDist := w.cfg.Params.FireEmberDistance
+	if maxDist < 2 {
+		maxDist = 2
+	}
+	dist := 2 + w.streams.fire.Float64()*float64(maxDist-2)*strength
+	jitter := (w.streams.fire.Float64() - 0.5) * 0.5
+	dirX := windX / speed
+	dirY := windY / speed
+	tx := int(math.Floor(float64(x) + 0.5 + dirX*dist - dirY*jitter*dist))
+	ty := int(math.Floor(float64(y) + 0.5 + dirY*dist + dirX*jitter*dist))
+	idx, ok := w.tileIndex(tx, ty)
+	if !ok {
+		return
+	}
+	if w.vegCurr[idx] == VegetationNone || w.groundCurr[idx] == GroundWater || w.burnTTL[idx] > 0 || w.burnNext[idx] > 0 {
+		return
+	}
+	landing := w.soilFireFactor(idx)
+	if idx < len(w.rainCurr) {
+		landing *= rainModifier(float64(w.rainCurr[idx]))
+	}
+	if landing < 1 && w.streams.fire.Float64() >= landing {
+		return
+	}
+
+	if ttl > 255 {
+		ttl = 255
+	}
+	w.burnNext[idx] = uint8(ttl)
+	if idx < len(w.display) {
+		w.display[idx] = 1
+	}
+}
+
+// IgniteAt manually starts a burn at the provided coordinates when vegetation
+// is present and fire is enabled.
 func (w *World) IgniteAt(x, y int) {
-	if x < 0 || y < 0 || x >= w.w || y >= w.h {
+	if !w.cfg.Params.Fire || x < 0 || y < 0 || x >= w.w || y >= w.h {
 		return
 	}
 	idx := y*w.w + x
@@ -3515,9 +3603,10 @@ func (w *World) IgniteAt(x, y int) {
 	w.rebuildDisplay()
 }
 
-// SpawnVolcanoAt triggers an immediate eruption centered on the provided coordinates.
+// SpawnVolcanoAt triggers an immediate eruption centered on the provided
+// coordinates when volcanoes are enabled.
 func (w *World) SpawnVolcanoAt(x, y int) {
-	if x < 0 || y < 0 || x >= w.w || y >= w.h {
+	if !w.cfg.Params.Volcanoes || x < 0 || y < 0 || x >= w.w || y >= w.h {
 		return
 	}
 
@@ -3556,7 +3645,7 @@ func (w *World) SpawnVolcanoAt(x, y int) {
 		radius:   radius,
 		strength: strength,
 		ttl:      ttl,
-		noise:    w.rng.Int63(),
+		noise:    w.streams.volcano.Int63(),
 	}
 
 	w.eruptRegion(region)
@@ -3564,7 +3653,7 @@ func (w *World) SpawnVolcanoAt(x, y int) {
 
 // Metrics exposes the latest vegetation telemetry.
 func (w *World) Metrics() VegetationMetrics {
-	m := w.metrics
+	m := w.vegetationMetrics()
 	if len(m.ClusterHistogram) > 0 {
 		m.ClusterHistogram = append([]int(nil), m.ClusterHistogram...)
 	}
@@ -3581,9 +3670,21 @@ func (w *World) EnvironmentSummary() EnvironmentMetrics {
 	var metrics EnvironmentMetrics
 	metrics.TotalTiles = total
 	metrics.ActiveRainRegions = len(w.rainRegions)
+	metrics.WeatherFronts = len(w.weather.fronts)
+	metrics.Eroded = w.erosionEvents.eroded
+	metrics.Deposited = w.erosionEvents.deposited
+	metrics.LightningStrikes = w.lightningEvents.strikes
+	metrics.LightningFires = w.lightningEvents.ignitions
 
-	var rainSum float64
+	var rainSum, soilSum float64
 	for i := 0; i < total; i++ {
+		if i < len(w.soilMoisture) {
+			soilSum += float64(w.soilMoisture[i])
+			if w.soilMoisture[i] < drySoilThreshold {
+				metrics.DrySoilTiles++
+			}
+		}
+
 		if i < len(w.groundCurr) {
 			switch w.groundCurr[i] {
 			case GroundDirt:
@@ -3594,9 +3695,28 @@ func (w *World) EnvironmentSummary() EnvironmentMetrics {
 				metrics.MountainTiles++
 			case GroundLava:
 				metrics.LavaTiles++
+			case GroundWater:
+				metrics.WaterTiles++
+			case GroundBasalt:
+				metrics.BasaltTiles++
+			case GroundSand:
+				metrics.SandTiles++
+			case GroundSnow:
+				metrics.SnowTiles++
+			case GroundWetland:
+				metrics.WetlandTiles++
+			case GroundTundra:
+				metrics.TundraTiles++
 			}
 		}
 
+		if i < len(w.waterDepth) {
+			metrics.WaterVolume += float64(w.waterDepth[i])
+		}
+		if i < len(w.sediment) {
+			metrics.SedimentLoad += float64(w.sediment[i])
+		}
+
 		if i < len(w.burnTTL) && w.burnTTL[i] > 0 {
 			metrics.BurningTiles++
 		}
@@ -3614,20 +3734,55 @@ func (w *World) EnvironmentSummary() EnvironmentMetrics {
 	}
 
 	metrics.RainMean = rainSum / float64(total)
+	metrics.SoilMoistureMean = soilSum / float64(total)
 	return metrics
 }
 
+// updateMetrics records the tick's vegetation turnover and marks buffer as
+// the vegetation whose census is owed to metrics.
 func (w *World) updateMetrics(buffer []Vegetation) {
-	total := w.w * w.h
-	if total == 0 {
-		w.metrics = VegetationMetrics{}
-		return
+	m := VegetationMetrics{ClusterHistogram: w.metrics.ClusterHistogram[:0]}
+	m.DroughtDeaths = w.vegEvents.droughtDeaths
+	m.CrowdingDeaths = w.vegEvents.crowdingDeaths
+	m.AgeDeaths = w.vegEvents.ageDeaths
+	m.SeedlingsEstablished = w.vegEvents.seedlings
+	m.LavaRegrowth = w.vegEvents.lavaRegrowth
+
+	w.metrics = m
+	w.censusSource = buffer
+	if w.w*w.h == 0 {
+		w.censusSource = nil
 	}
+}
 
-	var m VegetationMetrics
+// vegetationMetrics returns the current metrics, taking the census of the
+// vegetation first when it has not been taken since the last step. Counting
+// tiles and flood-filling clusters are full-map passes, so they only run for
+// ticks whose metrics are actually read.
+func (w *World) vegetationMetrics() VegetationMetrics {
+	if w.censusSource != nil {
+		start := time.Now()
+		w.takeCensus(w.censusSource)
+		w.censusSource = nil
+		w.profile.add(phaseMetrics, time.Since(start))
+	}
+	return w.metrics
+}
 
-	for i := 0; i < total; i++ {
-		switch buffer[i] {
+// takeCensus fills the stage counts, mean age and health, and cluster
+// histogram of w.metrics from buffer.
+func (w *World) takeCensus(buffer []Vegetation) {
+	total := w.w * w.h
+	if len(buffer) < total {
+		return
+	}
+	m := &w.metrics
+	ages := len(w.vegAge) == total && len(w.vegHealth) == total
+	var ageSum, healthSum float64
+	for i, veg := range buffer[:total] {
+		switch veg {
+		case VegetationNone:
+			continue
 		case VegetationGrass:
 			m.GrassTiles++
 		case VegetationShrub:
@@ -3635,23 +3790,38 @@ func (w *World) updateMetrics(buffer []Vegetation) {
 		case VegetationTree:
 			m.TreeTiles++
 		}
+		if ages {
+			ageSum += float64(w.vegAge[i])
+			healthSum += float64(w.vegHealth[i])
+		}
 	}
 	m.TotalVegetated = m.GrassTiles + m.ShrubTiles + m.TreeTiles
+	if m.TotalVegetated > 0 && ages {
+		m.MeanAge = ageSum / float64(m.TotalVegetated)
+		m.MeanHealth = healthSum / float64(m.TotalVegetated)
+	}
+	m.ClusterHistogram = w.clusterHistogram(buffer, m.ClusterHistogram)
+}
 
-	visited := make([]bool, total)
-	componentCounts := make(map[int]int)
-	maxSize := 0
+// clusterHistogram counts the Moore-connected vegetation components of
+// buffer by size, reusing hist's storage. The result is empty when nothing
+// grows.
+func (w *World) clusterHistogram(buffer []Vegetation, hist []int) []int {
+	hist = hist[:0]
+	total := w.w * w.h
+	if total == 0 || len(buffer) < total {
+		return hist
+	}
 
+	visited := w.clearedVisited()
+	stack := w.scratch.stack[:0]
 	for idx := 0; idx < total; idx++ {
-		if visited[idx] {
-			continue
-		}
-		if buffer[idx] == VegetationNone {
+		if visited[idx] || buffer[idx] == VegetationNone {
 			continue
 		}
 
 		size := 0
-		stack := []int{idx}
+		stack = append(stack[:0], idx)
 		visited[idx] = true
 		for len(stack) > 0 {
 			current := stack[len(stack)-1]
@@ -3661,23 +3831,20 @@ func (w *World) updateMetrics(buffer []Vegetation) {
 			cx := current % w.w
 			cy := current / w.w
 			for dy := -1; dy <= 1; dy++ {
-				ny := cy + dy
-				if ny < 0 || ny >= w.h {
+				ny, ok := w.wrapAxis(cy+dy, w.h)
+				if !ok {
 					continue
 				}
 				for dx := -1; dx <= 1; dx++ {
-					nx := cx + dx
-					if nx < 0 || nx >= w.w {
+					nx, ok := w.wrapAxis(cx+dx, w.w)
+					if !ok {
 						continue
 					}
 					if dx == 0 && dy == 0 {
 						continue
 					}
 					nIdx := ny*w.w + nx
-					if visited[nIdx] {
-						continue
-					}
-					if buffer[nIdx] == VegetationNone {
+					if visited[nIdx] || buffer[nIdx] == VegetationNone {
 						continue
 					}
 					visited[nIdx] = true
@@ -3686,63 +3853,87 @@ func (w *World) updateMetrics(buffer []Vegetation) {
 			}
 		}
 
-		componentCounts[size]++
-		if size > maxSize {
-			maxSize = size
-		}
-	}
-
-	if maxSize > 0 {
-		m.ClusterHistogram = make([]int, maxSize+1)
-		for size, count := range componentCounts {
-			m.ClusterHistogram[size] = count
+		for len(hist) <= size {
+			hist = append(hist, 0)
 		}
+		hist[size]++
 	}
-
-	w.metrics = m
+	w.scratch.stack = stack
+	return hist
 }
 
-func (w *World) mooreNeighborCounts() ([]uint8, []uint8) {
+// mooreNeighborCounts counts the grass, shrub, and tree neighbours of every
+// tile, packed into 4-bit lanes that unpackLanes splits. The slice is a
+// scratch buffer, valid until the next call.
+func (w *World) mooreNeighborCounts() []uint16 {
 	total := w.w * w.h
-	grassCounts := make([]uint8, total)
-	shrubCounts := make([]uint8, total)
+	sc := &w.scratch
+	sc.vegCounts = resize(sc.vegCounts, total)
+	counts := sc.vegCounts
 	if total == 0 {
-		return grassCounts, shrubCounts
+		return counts
+	}
+
+	// Each tile contributes a one in the 4-bit lane of its kind, so a single
+	// separable 3×3 box sum counts all three kinds at once; a lane never
+	// exceeds 9. The centre is subtracted at the end.
+	sc.vegLanes = resize(sc.vegLanes, total)
+	sc.vegRows = resize(sc.vegRows, total)
+	lanes, rows := sc.vegLanes, sc.vegRows
+	for i, veg := range w.vegCurr[:total] {
+		lanes[i] = vegetationLanes[veg]
 	}
 
 	for y := 0; y < w.h; y++ {
-		for x := 0; x < w.w; x++ {
-			idx := y*w.w + x
-			val := w.vegCurr[idx]
-			if val != VegetationGrass && val != VegetationShrub {
-				continue
+		row := y * w.w
+		in, out := lanes[row:row+w.w], rows[row:row+w.w]
+		for x := 1; x < len(in)-1 && x < len(out); x++ {
+			out[x] = in[x-1] + in[x] + in[x+1]
+		}
+		for _, x := range [2]int{0, w.w - 1} {
+			var sum uint16
+			for dx := -1; dx <= 1; dx++ {
+				if nx, ok := w.wrapAxis(x+dx, w.w); ok {
+					sum += lanes[row+nx]
+				}
 			}
+			rows[row+x] = sum
+		}
+	}
 
+	for y := 0; y < w.h; y++ {
+		row := y * w.w
+		if y > 0 && y < w.h-1 {
+			above, here, below := rows[row-w.w:row], rows[row:row+w.w], rows[row+w.w:row+2*w.w]
+			centre, out := lanes[row:row+w.w], counts[row:row+w.w]
+			for x := range out {
+				out[x] = above[x] + here[x] + below[x] - centre[x]
+			}
+			continue
+		}
+		for x := 0; x < w.w; x++ {
+			var sum uint16
 			for dy := -1; dy <= 1; dy++ {
-				ny := y + dy
-				if ny < 0 || ny >= w.h {
-					continue
-				}
-				for dx := -1; dx <= 1; dx++ {
-					nx := x + dx
-					if nx < 0 || nx >= w.w {
-						continue
-					}
-					if dx == 0 && dy == 0 {
-						continue
-					}
-					nIdx := ny*w.w + nx
-					if val == VegetationGrass {
-						grassCounts[nIdx]++
-					} else {
-						shrubCounts[nIdx]++
-					}
+				if ny, ok := w.wrapAxis(y+dy, w.h); ok {
+					sum += rows[ny*w.w+x]
 				}
 			}
+			idx := row + x
+			counts[idx] = sum - lanes[idx]
 		}
 	}
 
-	return grassCounts, shrubCounts
+	return counts
+}
+
+// vegetationLanes maps each vegetation kind to its lane in a packed
+// neighbour sum.
+var vegetationLanes = [...]uint16{VegetationGrass: 1, VegetationShrub: 1 << 4, VegetationTree: 1 << 8}
+
+// unpackLanes splits a packed neighbour sum into grass, shrub, and tree
+// counts.
+func unpackLanes(sum uint16) (uint8, uint8, uint8) {
+	return uint8(sum & 0xf), uint8(sum >> 4 & 0xf), uint8(sum >> 8 & 0xf)
 }
 
 func (w *World) sprinkleRock() {
@@ -3751,7 +3942,7 @@ func (w *World) sprinkleRock() {
 	}
 	total := w.w * w.h
 	for i := 0; i < total; i++ {
-		if w.rng.Float64() < w.cfg.Params.RockChance {
+		if w.rng.Float64() < w.cfg.Params.RockChance && w.groundCurr[i] == GroundDirt {
 			w.groundCurr[i] = GroundRock
 			w.display[i] = uint8(GroundRock)
 		} else {
@@ -3786,13 +3977,13 @@ func (w *World) seedGrassPatches() {
 		}
 		r2 := radius * radius
 		for dy := -radius; dy <= radius; dy++ {
-			yp := y + dy
-			if yp < 0 || yp >= w.h {
+			yp, ok := w.wrapAxis(y+dy, w.h)
+			if !ok {
 				continue
 			}
 			for dx := -radius; dx <= radius; dx++ {
-				xp := x + dx
-				if xp < 0 || xp >= w.w {
+				xp, ok := w.wrapAxis(x+dx, w.w)
+				if !ok {
 					continue
 				}
 				if dx*dx+dy*dy > r2 {
@@ -3802,6 +3993,9 @@ func (w *World) seedGrassPatches() {
 					continue
 				}
 				idx := yp*w.w + xp
+				if w.groundCurr[idx] == GroundWater || w.groundCurr[idx] == GroundMountain {
+					continue
+				}
 				w.vegCurr[idx] = VegetationGrass
 			}
 		}
@@ -3826,9 +4020,14 @@ func loadTectonicMap(w, h int) []float32 {
 	return vals
 }
 
+// The registered factory loads its config strictly, so a map that FromMap
+// would have accepted by skipping a bad key now fails with that key named.
 func init() {
-	core.Register("ecology", func(cfg map[string]string) core.Sim {
-		c := FromMap(cfg)
-		return NewWithConfig(c)
+	core.Register("ecology", func(cfg map[string]string) (core.Sim, error) {
+		c, err := LoadConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return NewWithConfig(c), nil
 	})
 }
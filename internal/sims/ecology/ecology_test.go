@@ -7,6 +7,33 @@ import (
 	"testing"
 )
 
+// newTestWorld resets a bare width×height world under seed for unit tests:
+// no seeded grass patches or rock. Each override adjusts the config
+// before the world is built.
+func newTestWorld(t *testing.T, width, height int, seed int64, overrides ...func(*Config)) *World {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.Width = width
+	cfg.Height = height
+	cfg.Seed = seed
+	cfg.Params.GrassPatchCount = 0
+	cfg.Params.RockChance = 0
+	for _, override := range overrides {
+		override(&cfg)
+	}
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+	return world
+}
+
+// fillVegetation covers every tile of world with v.
+func fillVegetation(world *World, v Vegetation) {
+	for i := range world.vegCurr {
+		world.vegCurr[i] = v
+	}
+	copy(world.vegNext, world.vegCurr)
+}
+
 func TestResetDeterministic(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.Width = 32
@@ -193,7 +220,7 @@ func TestSpawnVolcanoProtoRespectsTectonicThreshold(t *testing.T) {
 
 	world := NewWithConfig(cfg)
 	world.Reset(0)
-	world.rng.Seed(12345)
+	world.streams.volcano.Seed(12345)
 
 	for i := range world.tectonic {
 		world.tectonic[i] = 0
@@ -838,3 +865,124 @@ func TestRainRegionsShareGlobalWindField(t *testing.T) {
 		}
 	}
 }
+
+func TestInspectCellReportsLayers(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Width = 4
+	cfg.Height = 3
+	cfg.Seed = 5
+	cfg.Params.GrassPatchCount = 0
+	cfg.Params.RockChance = 0
+
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+
+	idx := 1*cfg.Width + 2
+	world.groundCurr[idx] = GroundLava
+	world.vegCurr[idx] = VegetationShrub
+	world.lavaDir[idx] = 2
+
+	values := map[string]string{}
+	for _, v := range world.InspectCell(2, 1) {
+		values[v.Name] = v.Value
+	}
+	expect := map[string]string{
+		"Ground":     "lava",
+		"Vegetation": "shrub",
+		"Lava dir":   "S",
+	}
+	for name, want := range expect {
+		if got := values[name]; got != want {
+			t.Fatalf("expected %s=%q, got %q", name, want, got)
+		}
+	}
+	if _, ok := values["Wind"]; !ok {
+		t.Fatal("expected wind vector in inspector output")
+	}
+
+	if got := world.InspectCell(cfg.Width, 0); got != nil {
+		t.Fatalf("expected nil for out-of-bounds cell, got %v", got)
+	}
+}
+
+func TestFieldsExposeLayersAsFloat32(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Width = 4
+	cfg.Height = 3
+	cfg.Seed = 5
+	cfg.Params.GrassPatchCount = 0
+
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+
+	idx := 1*cfg.Width + 2
+	world.lavaHeight[idx] = 3
+	world.lavaTip[idx] = true
+	world.burnTTL[idx] = 2
+	world.lavaElevation[idx] = -40
+	world.lavaChannel[idx] = 0.25
+
+	total := cfg.Width * cfg.Height
+	for _, info := range world.Fields() {
+		values := world.Field(info.Key)
+		if len(values) != total {
+			t.Fatalf("field %q: expected %d values, got %d", info.Key, total, len(values))
+		}
+		if info.Label == "" || info.Type == "" {
+			t.Fatalf("field %q missing label or type", info.Key)
+		}
+	}
+
+	expect := map[string]float32{
+		"lava_height":  3,
+		"lava_tip":     1,
+		"burn_ttl":     2,
+		"elevation":    -40,
+		"lava_channel": 0.25,
+	}
+	for key, want := range expect {
+		if got := world.Field(key)[idx]; got != want {
+			t.Fatalf("field %q: expected %v at tile, got %v", key, want, got)
+		}
+	}
+	if world.Field("missing") != nil {
+		t.Fatal("expected nil for unknown field key")
+	}
+}
+
+func TestFieldAtMatchesField(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Width = 24
+	cfg.Height = 16
+	cfg.Seed = 9
+	cfg.Terrain = TerrainNoise
+	cfg.Params.Weather = true
+	cfg.Params.Biomes = true
+	cfg.Params.HerbivoreDensity = 0.2
+	cfg.Params.PredatorDensity = 0.05
+
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+	world.SpawnVolcanoAt(12, 8)
+	for tick := 0; tick < 20; tick++ {
+		world.Step()
+	}
+
+	for _, info := range world.Fields() {
+		values := slices.Clone(world.Field(info.Key))
+		for y := 0; y < cfg.Height; y++ {
+			for x := 0; x < cfg.Width; x++ {
+				got, ok := world.FieldAt(info.Key, x, y)
+				if want := values[y*cfg.Width+x]; !ok || got != want {
+					t.Fatalf("field %q at (%d,%d): FieldAt = %v, %v; Field = %v", info.Key, x, y, got, ok, want)
+				}
+			}
+		}
+		if _, ok := world.FieldAt(info.Key, cfg.Width, 0); ok {
+			t.Fatalf("field %q: expected no value outside the grid", info.Key)
+		}
+	}
+	if _, ok := world.FieldAt("missing", 0, 0); ok {
+		t.Fatal("expected no value for unknown field key")
+	}
+}
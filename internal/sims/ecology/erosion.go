@@ -0,0 +1,136 @@
+package ecology
+
+import "math"
+
+// erosionEvents tallies the elevation moved by erosion during one tick.
+type erosionEvents struct {
+	eroded    float64
+	deposited float64
+}
+
+// applyErosion wears the elevation field down on a rolling window of at most
+// ErosionBudget tiles per tick, so the cost stays flat on large worlds. Each
+// visited tile is treated as if the ticks since its last visit had elapsed
+// at once, which keeps long-run rates independent of the budget.
+func (w *World) applyErosion() {
+	w.erosionEvents = erosionEvents{}
+	total := w.w * w.h
+	budget := w.cfg.Params.ErosionBudget
+	if total == 0 || budget <= 0 || len(w.sediment) != total || len(w.erosionDebt) != total {
+		return
+	}
+	budget = min(budget, total)
+	dt := float64((total + budget - 1) / budget)
+
+	height := func(i int) float64 {
+		return float64(w.lavaElevation[i]) + float64(w.erosionDebt[i])
+	}
+	for n := 0; n < budget; n++ {
+		if w.erosionCursor >= total {
+			w.erosionCursor = 0
+		}
+		w.erodeTile(w.erosionCursor, dt, height)
+		w.erosionCursor++
+	}
+}
+
+// erodeTile removes material from idx in proportion to rain, surface flow,
+// and slope, plus thermal slumping on slopes steeper than ErosionTalus, and
+// hands it to the steepest downhill neighbour as sediment. Sediment that
+// reaches a gentle slope or a pit settles and raises the ground there.
+func (w *World) erodeTile(idx int, dt float64, height func(int) float64) {
+	if w.groundCurr[idx] == GroundLava {
+		return
+	}
+	params := &w.cfg.Params
+	carried := float64(w.sediment[idx])
+
+	target, _, drop := w.steepestDescent(idx, height)
+	if target < 0 || drop <= params.ErosionDepositSlope {
+		if carried > 0 {
+			w.sediment[idx] = 0
+			w.shiftElevation(idx, carried)
+			w.wearGround(idx, carried, true)
+			w.erosionEvents.deposited += carried
+		}
+		return
+	}
+
+	wetness := 0.0
+	if idx < len(w.rainCurr) {
+		wetness += params.ErosionRainRate * float64(w.rainCurr[idx])
+	}
+	if idx < len(w.waterFlow) {
+		wetness += params.ErosionFlowRate * float64(w.waterFlow[idx])
+	}
+	amount := dt * wetness * drop
+	if talus := params.ErosionTalus; drop > talus {
+		amount += dt * params.ErosionThermalRate * (drop - talus)
+	}
+	// Never cut below the receiving neighbour or below sea level.
+	amount = math.Min(amount, math.Min(drop/2, height(idx)))
+	if amount > 0 {
+		w.shiftElevation(idx, -amount)
+		w.wearGround(idx, amount, false)
+		w.erosionEvents.eroded += amount
+	}
+
+	w.sediment[idx] = 0
+	w.sediment[target] += float32(carried + math.Max(amount, 0))
+}
+
+// shiftElevation accumulates a fractional elevation change at idx and applies
+// whole units to lavaElevation. Deposits also raise terrainElevation, and
+// erosion keeps it from standing above the eroded surface.
+func (w *World) shiftElevation(idx int, delta float64) {
+	debt := float64(w.erosionDebt[idx]) + delta
+	whole := math.Trunc(debt)
+	if whole != 0 {
+		elev := clampFloat(float64(w.lavaElevation[idx])+whole, 0, math.MaxInt16)
+		w.lavaElevation[idx] = int16(elev)
+		if idx < len(w.terrainElevation) {
+			ground := float64(w.terrainElevation[idx])
+			if whole > 0 {
+				ground = math.Min(ground+whole, math.MaxInt16)
+			}
+			w.terrainElevation[idx] = int16(math.Min(ground, elev))
+		}
+		debt -= whole
+	}
+	w.erosionDebt[idx] = float32(debt)
+}
+
+// wearGround tracks material stripped from (or buried onto) idx. Exposed
+// mountain crumbles to rock after ErosionMountainWear units and rock and
+// basalt break down to the tile's land ground after ErosionRockWear;
+// sediment burying them counts toward the same soil formation.
+func (w *World) wearGround(idx int, amount float64, deposit bool) {
+	ground := w.groundCurr[idx]
+	if ground != GroundMountain && ground != GroundRock && ground != GroundBasalt {
+		return
+	}
+	if deposit && ground == GroundMountain {
+		return
+	}
+	wear := float64(w.erosionWear[idx]) + amount
+	next := ground
+	switch ground {
+	case GroundMountain:
+		if wear >= w.cfg.Params.ErosionMountainWear {
+			next = GroundRock
+		}
+	case GroundRock, GroundBasalt:
+		if wear >= w.cfg.Params.ErosionRockWear {
+			next = w.landGround(idx)
+		}
+	}
+	if next != ground {
+		w.groundCurr[idx] = next
+		w.groundNext[idx] = next
+		if idx < len(w.lavaScar) {
+			w.lavaScar[idx] = 0
+		}
+		wear = 0
+	}
+	w.erosionWear[idx] = float32(wear)
+}
@@ -0,0 +1,132 @@
+package ecology
+
+import (
+	"math"
+	"testing"
+)
+
+// noThermalErosion leaves rain and flow as the only erosion drivers.
+func noThermalErosion(cfg *Config) {
+	cfg.Params.ErosionThermalRate = 0
+}
+
+// erosionMass sums the elevation field, unapplied elevation debt, and
+// sediment in transit, which erosion must conserve.
+func erosionMass(world *World) float64 {
+	mass := 0.0
+	for i := range world.lavaElevation {
+		mass += float64(world.lavaElevation[i]) + float64(world.erosionDebt[i]) + float64(world.sediment[i])
+	}
+	return mass
+}
+
+func TestRainErodesSlopesIntoValleySediment(t *testing.T) {
+	world := newTestWorld(t, 10, 1, 17, noThermalErosion)
+	world.cfg.Params.ErosionRainRate = 0.05
+	for x := 0; x < 10; x++ {
+		world.lavaElevation[x] = int16(max(20-4*x, 0))
+		world.terrainElevation[x] = world.lavaElevation[x]
+		world.rainCurr[x] = 1
+	}
+	start := erosionMass(world)
+	peak := world.lavaElevation[0]
+
+	for tick := 0; tick < 400; tick++ {
+		world.applyErosion()
+	}
+
+	if world.lavaElevation[0] >= peak {
+		t.Fatalf("rain should wear the slope top down, still %d", world.lavaElevation[0])
+	}
+	if world.lavaElevation[9] <= 0 {
+		t.Fatalf("sediment should settle in the valley floor, got %d", world.lavaElevation[9])
+	}
+	if got := erosionMass(world); math.Abs(got-start) > 1e-2 {
+		t.Fatalf("erosion should only move material: start %.3f, now %.3f", start, got)
+	}
+	for i := range world.lavaElevation {
+		if world.terrainElevation[i] > world.lavaElevation[i] {
+			t.Fatalf("terrain base at %d stands above the eroded surface", i)
+		}
+	}
+}
+
+func TestThermalErosionSlumpsCliffsWithoutRain(t *testing.T) {
+	world := newTestWorld(t, 3, 1, 17, noThermalErosion)
+	world.cfg.Params.ErosionRainRate = 0
+	world.cfg.Params.ErosionFlowRate = 0
+	world.cfg.Params.ErosionThermalRate = 0.05
+	world.cfg.Params.ErosionTalus = 2
+	world.lavaElevation[0] = 12
+
+	for tick := 0; tick < 300; tick++ {
+		world.applyErosion()
+	}
+
+	if world.lavaElevation[0] >= 12 || world.lavaElevation[1] == 0 {
+		t.Fatalf("cliff should slump onto its foot, got %v", world.lavaElevation)
+	}
+	if drop := world.lavaElevation[0] - world.lavaElevation[1]; drop > 3 {
+		t.Fatalf("slumping should relax the cliff toward the talus slope, drop %d", drop)
+	}
+}
+
+func TestErosionWearsMountainToRockToDirt(t *testing.T) {
+	world := newTestWorld(t, 8, 1, 17, noThermalErosion)
+	world.cfg.Params.ErosionRainRate = 0.02
+	world.cfg.Params.ErosionMountainWear = 1
+	world.cfg.Params.ErosionRockWear = 2
+	world.groundCurr[0] = GroundMountain
+	world.lavaElevation[0] = 30
+	world.rainCurr[0] = 1
+
+	seen := map[Ground]bool{}
+	for tick := 0; tick < 400 && world.groundCurr[0] != GroundDirt; tick++ {
+		world.applyErosion()
+		seen[world.groundCurr[0]] = true
+	}
+
+	if !seen[GroundRock] || world.groundCurr[0] != GroundDirt {
+		t.Fatalf("exposed mountain should wear to rock and then dirt, saw %v ending on %v", seen, world.groundCurr[0])
+	}
+}
+
+func TestErosionBudgetLimitsTilesPerTick(t *testing.T) {
+	world := newTestWorld(t, 4, 4, 17, noThermalErosion)
+	world.cfg.Params.ErosionBudget = 5
+	world.cfg.Params.ErosionRainRate = 0.1
+	for i := range world.lavaElevation {
+		world.lavaElevation[i] = int16(16 - i)
+		world.rainCurr[i] = 1
+	}
+
+	world.applyErosion()
+	if world.erosionCursor != 5 {
+		t.Fatalf("expected the cursor to advance by the budget, got %d", world.erosionCursor)
+	}
+	for i := 5; i < 16; i++ {
+		if world.erosionDebt[i] != 0 || world.lavaElevation[i] != int16(16-i) {
+			t.Fatalf("tile %d beyond the budget window was eroded", i)
+		}
+	}
+
+	for tick := 0; tick < 3; tick++ {
+		world.applyErosion()
+	}
+	if world.erosionCursor != 4 {
+		t.Fatalf("cursor should wrap around the world, got %d", world.erosionCursor)
+	}
+}
+
+func TestErosionSwitchGatesStep(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		world := newTestWorld(t, 4, 4, 17, func(cfg *Config) {
+			cfg.Params.Erosion = enabled
+			cfg.Params.ErosionBudget = 5
+		})
+		world.Step()
+		if advanced := world.erosionCursor != 0; advanced != enabled {
+			t.Fatalf("erosion=%v: cursor advanced = %v", enabled, advanced)
+		}
+	}
+}
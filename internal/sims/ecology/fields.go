@@ -0,0 +1,196 @@
+package ecology
+
+import "mad-ca/internal/core"
+
+// Fields lists the per-cell layers exported to overlays, the inspector, and
+// exporters. Overlays page through them in this order, so keep the commonly
+// inspected masks at the front.
+func (w *World) Fields() []core.FieldInfo {
+	return []core.FieldInfo{
+		{Key: "rain", Label: "Rain", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "rain"},
+		{Key: "volcano", Label: "Volcano", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "volcano"},
+		{Key: "elevation", Label: "Elevation", Type: core.FieldTypeInt16, Colormap: "elevation", Dense: true},
+		{Key: "heat", Label: "Heat", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "heat"},
+		{Key: "water_depth", Label: "Water depth", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "rain"},
+		{Key: "soil_moisture", Label: "Soil moisture", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "viridis", Dense: true},
+		{Key: "veg_age", Label: "Plant age", Type: core.FieldTypeInt16, Units: "ticks", Colormap: "viridis"},
+		{Key: "veg_health", Label: "Plant health", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "viridis"},
+		{Key: "lava_temp", Label: "Lava temp", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "magma"},
+		{Key: "lava_channel", Label: "Channel", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "magma"},
+		{Key: "burn_ttl", Label: "Burn TTL", Type: core.FieldTypeUint8, Units: "ticks", Min: 0, Max: float32(max(w.cfg.Params.BurnTTL, 1)), Colormap: "heat"},
+		{Key: "tectonic", Label: "Tectonic", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "grayscale", Dense: true},
+		{Key: "lava_height", Label: "Lava height", Type: core.FieldTypeUint8, Min: 0, Max: float32(w.lavaMaxHeight()), Colormap: "magma"},
+		{Key: "lava_dir", Label: "Lava dir", Type: core.FieldTypeInt8, Min: -1, Max: 7},
+		{Key: "lava_tip", Label: "Lava tip", Type: core.FieldTypeBool, Min: 0, Max: 1},
+		{Key: "lava_history", Label: "Flow history", Type: core.FieldTypeInt16, Units: "layers", Colormap: "magma"},
+		{Key: "water_flow", Label: "Water flow", Type: core.FieldTypeFloat32, Min: 0, Max: 0.1, Colormap: "rain"},
+		{Key: "sediment", Label: "Sediment", Type: core.FieldTypeFloat32, Min: 0, Max: 0.5, Colormap: "viridis"},
+		{Key: "humidity", Label: "Humidity", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "rain"},
+		{Key: "pressure", Label: "Pressure", Type: core.FieldTypeFloat32, Min: 0, Max: 1, Colormap: "viridis"},
+		{Key: "ground", Label: "Ground", Type: core.FieldTypeUint8, Min: 0, Max: float32(GroundTundra), Dense: true},
+		{Key: "vegetation", Label: "Vegetation", Type: core.FieldTypeUint8, Min: 0, Max: float32(VegetationTree)},
+		{Key: "climate_zone", Label: "Climate zone", Type: core.FieldTypeUint8, Min: 0, Max: float32(GroundTundra), Dense: true},
+		{Key: "agents", Label: "Agent", Type: core.FieldTypeUint8, Min: 0, Max: float32(AgentPredator)},
+	}
+}
+
+// Field returns the named layer as float32 values. Float layers alias the
+// live buffers; integer layers are converted into a per-key scratch buffer.
+func (w *World) Field(key string) []float32 {
+	switch key {
+	case "rain":
+		return w.rainCurr
+	case "volcano":
+		return w.volCurr
+	case "heat":
+		return w.heatField
+	case "water_depth":
+		return w.waterDepth
+	case "water_flow":
+		return w.waterFlow
+	case "sediment":
+		return w.sediment
+	case "soil_moisture":
+		return w.soilMoisture
+	case "veg_health":
+		return w.vegHealth
+	case "lava_temp":
+		return w.lavaTemp
+	case "lava_channel":
+		return w.lavaChannel
+	case "tectonic":
+		return w.tectonic
+	case "elevation":
+		return w.storeField(key, core.FieldFromInt16(w.fieldScratch[key], w.lavaElevation))
+	case "veg_age":
+		return w.storeField(key, core.FieldFromInt16(w.fieldScratch[key], w.vegAge))
+	case "burn_ttl":
+		return w.storeField(key, core.FieldFromUint8(w.fieldScratch[key], w.burnTTL))
+	case "lava_height":
+		return w.storeField(key, core.FieldFromUint8(w.fieldScratch[key], w.lavaHeight))
+	case "lava_history":
+		return w.storeField(key, core.FieldFromInt16(w.fieldScratch[key], w.basaltDepth))
+	case "lava_dir":
+		return w.storeField(key, core.FieldFromInt8(w.fieldScratch[key], w.lavaDir))
+	case "lava_tip":
+		return w.storeField(key, core.FieldFromBool(w.fieldScratch[key], w.lavaTip))
+	case "ground":
+		return w.storeField(key, core.FieldFromUint8(w.fieldScratch[key], w.groundCurr))
+	case "vegetation":
+		return w.storeField(key, core.FieldFromUint8(w.fieldScratch[key], w.vegCurr))
+	case "humidity":
+		return w.storeField(key, w.weatherField(w.fieldScratch[key], w.weather.humidity))
+	case "pressure":
+		return w.storeField(key, w.weatherField(w.fieldScratch[key], w.weather.pressure))
+	case "climate_zone":
+		return w.storeField(key, core.FieldFromUint8(w.fieldScratch[key], w.climateZone))
+	case "agents":
+		return w.storeField(key, w.agentField(w.fieldScratch[key]))
+	default:
+		return nil
+	}
+}
+
+// FieldAt returns one tile of the named layer, reading the live buffers
+// directly instead of converting the whole grid as Field does.
+func (w *World) FieldAt(key string, x, y int) (float32, bool) {
+	if x < 0 || y < 0 || x >= w.w || y >= w.h {
+		return 0, false
+	}
+	i := y*w.w + x
+	switch key {
+	case "rain":
+		return cellValue(w.rainCurr, i)
+	case "volcano":
+		return cellValue(w.volCurr, i)
+	case "heat":
+		return cellValue(w.heatField, i)
+	case "water_depth":
+		return cellValue(w.waterDepth, i)
+	case "water_flow":
+		return cellValue(w.waterFlow, i)
+	case "sediment":
+		return cellValue(w.sediment, i)
+	case "soil_moisture":
+		return cellValue(w.soilMoisture, i)
+	case "veg_health":
+		return cellValue(w.vegHealth, i)
+	case "lava_temp":
+		return cellValue(w.lavaTemp, i)
+	case "lava_channel":
+		return cellValue(w.lavaChannel, i)
+	case "tectonic":
+		return cellValue(w.tectonic, i)
+	case "elevation":
+		return cellValue(w.lavaElevation, i)
+	case "veg_age":
+		return cellValue(w.vegAge, i)
+	case "burn_ttl":
+		return cellValue(w.burnTTL, i)
+	case "lava_height":
+		return cellValue(w.lavaHeight, i)
+	case "lava_history":
+		return cellValue(w.basaltDepth, i)
+	case "lava_dir":
+		return cellValue(w.lavaDir, i)
+	case "lava_tip":
+		if i >= len(w.lavaTip) {
+			return 0, false
+		}
+		if w.lavaTip[i] {
+			return 1, true
+		}
+		return 0, true
+	case "ground":
+		return cellValue(w.groundCurr, i)
+	case "vegetation":
+		return cellValue(w.vegCurr, i)
+	case "humidity":
+		return w.weatherAt(w.weather.humidity, x, y), true
+	case "pressure":
+		return w.weatherAt(w.weather.pressure, x, y), true
+	case "climate_zone":
+		return cellValue(w.climateZone, i)
+	case "agents":
+		if i >= len(w.agentCell) {
+			return 0, false
+		}
+		if slot := w.agentCell[i]; slot > 0 {
+			return float32(w.agents[slot-1].Kind), true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// cellValue reads values[i] as a float32 field value.
+func cellValue[T ~uint8 | ~int8 | ~int16 | ~float32](values []T, i int) (float32, bool) {
+	if i >= len(values) {
+		return 0, false
+	}
+	return float32(values[i]), true
+}
+
+func (w *World) storeField(key string, values []float32) []float32 {
+	if w.fieldScratch == nil {
+		w.fieldScratch = make(map[string][]float32)
+	}
+	w.fieldScratch[key] = values
+	return values
+}
+
+// agentField rasterises the agent list into dst as species codes.
+func (w *World) agentField(dst []float32) []float32 {
+	total := w.w * w.h
+	if len(dst) != total {
+		dst = make([]float32, total)
+	}
+	for i := range dst {
+		dst[i] = 0
+	}
+	for _, a := range w.agents {
+		dst[a.Y*w.w+a.X] = float32(a.Kind)
+	}
+	return dst
+}
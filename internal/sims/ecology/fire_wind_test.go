@@ -0,0 +1,118 @@
+package ecology
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestFireWindModifierFavoursDownwind(t *testing.T) {
+	const bias = 1.5
+	windX, windY := 0.8, 0.0
+
+	down := fireWindModifier(1, 0, windX, windY, bias)
+	cross := fireWindModifier(0, 1, windX, windY, bias)
+	up := fireWindModifier(-1, 0, windX, windY, bias)
+	if !(down > cross && cross > up) {
+		t.Fatalf("expected downwind > crosswind > upwind, got %.3f %.3f %.3f", down, cross, up)
+	}
+	if math.Abs(cross-1) > 1e-9 {
+		t.Fatalf("crosswind neighbours should be unaffected, got %.3f", cross)
+	}
+	if up != 0 {
+		t.Fatalf("strong bias should suppress upwind spread entirely, got %.3f", up)
+	}
+	if got := fireWindModifier(-1, 0, windX, windY, 0); got != 1 {
+		t.Fatalf("zero bias must keep spread isotropic, got %.3f", got)
+	}
+}
+
+// windFireOnly leaves fire as the only process that changes vegetation.
+func windFireOnly(cfg *Config) {
+	cfg.Params.RainSpawnChance = 0
+	cfg.Params.VolcanoProtoSpawnChance = 0
+	cfg.Params.GrassSpreadChance = 0
+	cfg.Params.ShrubGrowthChance = 0
+	cfg.Params.TreeGrowthChance = 0
+	cfg.Params.BurnTTL = 4
+	cfg.Params.WindSpeedScale = 0.8
+}
+
+func TestFireFrontDriftsDownwind(t *testing.T) {
+	run := func() ([]uint8, []Vegetation, float64, float64) {
+		world := newTestWorld(t, 41, 41, 21, windFireOnly)
+		fillVegetation(world, VegetationGrass)
+		world.cfg.Params.FireSpreadChance = 0.35
+		world.cfg.Params.FireWindBias = 2
+
+		cx, cy := 20, 20
+		windX, windY := world.windVector(float64(cx)+0.5, float64(cy)+0.5)
+		world.IgniteAt(cx, cy)
+		for i := 0; i < 12; i++ {
+			world.applyFire()
+		}
+
+		// Centroid of everything the fire reached: burning or burnt out.
+		var sumX, sumY, count float64
+		for idx := range world.vegCurr {
+			if world.burnTTL[idx] > 0 || world.vegCurr[idx] == VegetationNone {
+				sumX += float64(idx%world.w) - float64(cx)
+				sumY += float64(idx/world.w) - float64(cy)
+				count++
+			}
+		}
+		if count == 0 {
+			t.Fatal("fire never spread")
+		}
+		drift := (sumX*windX + sumY*windY) / count / math.Hypot(windX, windY)
+		return append([]uint8(nil), world.burnTTL...), append([]Vegetation(nil), world.vegCurr...), drift, count
+	}
+
+	burnA, vegA, drift, burned := run()
+	burnB, vegB, _, _ := run()
+	if !slices.Equal(burnA, burnB) || !slices.Equal(vegA, vegB) {
+		t.Fatal("wind-driven fire is not deterministic for a fixed seed")
+	}
+	if burned < 10 {
+		t.Fatalf("expected the fire to spread, only %v tiles reached", burned)
+	}
+	if drift < 1 {
+		t.Fatalf("expected fire front centroid to drift downwind, got %.3f tiles", drift)
+	}
+}
+
+func TestEmbersLandDownwindBeyondNeighbours(t *testing.T) {
+	world := newTestWorld(t, 41, 41, 21, windFireOnly)
+	fillVegetation(world, VegetationGrass)
+	world.cfg.Params.FireSpreadChance = 0
+	world.cfg.Params.FireEmberChance = 1
+	world.cfg.Params.FireEmberDistance = 8
+
+	cx, cy := 20, 20
+	windX, windY := world.windVector(float64(cx)+0.5, float64(cy)+0.5)
+	world.IgniteAt(cx, cy)
+	world.burnTTL[cy*world.w+cx] = 200
+
+	spots := 0
+	for i := 0; i < 40; i++ {
+		world.applyFire()
+		for idx, ttl := range world.burnTTL {
+			if ttl == 0 || idx == cy*world.w+cx {
+				continue
+			}
+			dx := float64(idx%world.w - cx)
+			dy := float64(idx/world.w - cy)
+			if math.Max(math.Abs(dx), math.Abs(dy)) < 2 {
+				t.Fatalf("ember landed adjacent to the source at (%v,%v)", dx, dy)
+			}
+			if dx*windX+dy*windY <= 0 {
+				t.Fatalf("ember landed upwind at (%v,%v) for wind (%.3f,%.3f)", dx, dy, windX, windY)
+			}
+			spots++
+			world.burnTTL[idx] = 0
+		}
+	}
+	if spots == 0 {
+		t.Fatal("expected embers to start spot fires")
+	}
+}
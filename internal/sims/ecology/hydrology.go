@@ -0,0 +1,102 @@
+package ecology
+
+import "math"
+
+// waterFlowMemory is the smoothing factor of the per-tile outflow average
+// that distinguishes river channels from transient sheet flow.
+const waterFlowMemory = 0.8
+
+// applyHydrology advances the surface-water layer: rain adds depth, water
+// evaporates and soaks into the soil, then flows toward the steepest descent
+// of the water surface (terrain elevation plus depth). Deep or fast-flowing
+// tiles become open water, drowning vegetation, and open water quenches any
+// lava it touches.
+func (w *World) applyHydrology() {
+	total := w.w * w.h
+	if total == 0 || len(w.waterDepth) != total || len(w.waterNext) != total {
+		return
+	}
+	params := w.cfg.Params
+
+	for i := 0; i < total; i++ {
+		depth := float64(w.waterDepth[i])
+		if i < len(w.rainCurr) {
+			depth += params.WaterRainGain * float64(w.rainCurr[i])
+		}
+		if w.groundCurr[i] == GroundLava {
+			depth = 0
+		}
+		if depth <= 0 {
+			w.waterDepth[i] = 0
+			continue
+		}
+		depth -= params.WaterEvaporation
+		if w.groundCurr[i] != GroundWater && i < len(w.soilMoisture) {
+			soil := float64(w.soilMoisture[i])
+			soak := math.Min(params.WaterInfiltration*(1-soil), math.Max(depth, 0))
+			depth -= soak
+			w.soilMoisture[i] = float32(clamp01(soil + soak))
+		}
+		w.waterDepth[i] = float32(math.Max(depth, 0))
+	}
+
+	copy(w.waterNext, w.waterDepth)
+	surface := func(i int) float64 {
+		return float64(w.lavaElevation[i]) + float64(w.waterDepth[i])
+	}
+	for i := 0; i < total; i++ {
+		if w.waterDepth[i] <= 0 && w.waterFlow[i] == 0 {
+			// Dry and still: the flow average stays at zero.
+			continue
+		}
+		outflow := 0.0
+		if depth := float64(w.waterDepth[i]); depth > 0 {
+			if target, _, drop := w.steepestDescent(i, surface); target >= 0 && drop > 0 {
+				outflow = math.Min(depth, params.WaterFlowRate*drop/2)
+				w.waterNext[i] -= float32(outflow)
+				w.waterNext[target] += float32(outflow)
+			}
+		}
+		w.waterFlow[i] = float32(float64(w.waterFlow[i])*waterFlowMemory + outflow*(1-waterFlowMemory))
+	}
+	w.waterDepth, w.waterNext = w.waterNext, w.waterDepth
+
+	lake := params.WaterLakeDepth
+	river := params.WaterRiverFlow
+	for i := 0; i < total; i++ {
+		depth := float64(w.waterDepth[i])
+		flow := float64(w.waterFlow[i])
+		switch w.groundCurr[i] {
+		case GroundLava:
+			if w.touchesOpenWater(i) {
+				w.quenchLava(i)
+			}
+		case GroundWater:
+			if depth < lake/2 && flow < river/2 {
+				w.groundCurr[i] = w.landGround(i)
+			}
+		case GroundDirt, GroundRock, GroundBasalt, GroundSand, GroundSnow, GroundWetland, GroundTundra:
+			if depth >= lake || (river > 0 && flow >= river) {
+				w.groundCurr[i] = GroundWater
+				w.vegCurr[i] = VegetationNone
+				w.burnTTL[i] = 0
+			}
+		}
+	}
+}
+
+// touchesOpenWater reports whether any Moore neighbour of idx is open water.
+func (w *World) touchesOpenWater(idx int) bool {
+	x := idx % w.w
+	y := idx / w.w
+	for _, dir := range lavaDirections {
+		nIdx, ok := w.tileIndex(x+dir.dx, y+dir.dy)
+		if !ok {
+			continue
+		}
+		if w.groundCurr[nIdx] == GroundWater {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package ecology
+
+import (
+	"math"
+	"testing"
+)
+
+// stillWater stops surface water from evaporating or soaking in.
+func stillWater(cfg *Config) {
+	cfg.Params.WaterEvaporation = 0
+	cfg.Params.WaterInfiltration = 0
+}
+
+func TestWaterRunsDownhillIntoLake(t *testing.T) {
+	world := newTestWorld(t, 12, 1, 3, stillWater)
+	world.cfg.Params.WaterRainGain = 0.2
+	for x := 0; x < 12; x++ {
+		world.lavaElevation[x] = int16(12 - x)
+	}
+	world.lavaElevation[11] = 0
+
+	added := 0.0
+	for tick := 0; tick < 60; tick++ {
+		world.rainCurr[0] = 1
+		added += world.cfg.Params.WaterRainGain
+		world.applyHydrology()
+	}
+
+	volume := 0.0
+	for _, d := range world.waterDepth {
+		volume += float64(d)
+	}
+	if math.Abs(volume-added) > 1e-3 {
+		t.Fatalf("flow should conserve water without sinks: added %.3f, holding %.3f", added, volume)
+	}
+	if world.groundCurr[11] != GroundWater {
+		t.Fatalf("water should pool into a lake at the bottom of the slope, got %v (depth %.3f)", world.groundCurr[11], world.waterDepth[11])
+	}
+	if world.groundCurr[0] == GroundWater && world.waterDepth[0] >= float32(world.cfg.Params.WaterLakeDepth) {
+		t.Fatalf("water should not pond on the slope top, depth %.3f", world.waterDepth[0])
+	}
+
+	river := 0
+	for x := 1; x < 11; x++ {
+		if world.groundCurr[x] == GroundWater && world.waterDepth[x] < float32(world.cfg.Params.WaterLakeDepth) {
+			river++
+		}
+	}
+	if river == 0 {
+		t.Fatalf("sustained shallow flow along the slope should form a river channel")
+	}
+}
+
+func TestHydrologySwitchGatesSurfaceWater(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		world := newTestWorld(t, 3, 1, 3, stillWater, func(cfg *Config) {
+			cfg.Params.Rain = false
+			cfg.Params.Hydrology = enabled
+		})
+		world.lavaElevation[0] = 4
+		world.waterDepth[0] = 0.4
+
+		world.Step()
+
+		moved := world.waterDepth[0] < 0.4
+		if moved != enabled {
+			t.Fatalf("hydrology=%v: water left the top tile = %v (depth %.3f)", enabled, moved, world.waterDepth[0])
+		}
+	}
+}
+
+func TestOpenWaterDrownsVegetationAndDriesBack(t *testing.T) {
+	world := newTestWorld(t, 2, 1, 3, stillWater)
+	world.vegCurr[0] = VegetationTree
+	world.burnTTL[0] = 2
+	world.waterDepth[0] = 1
+	world.waterDepth[1] = 1
+
+	world.applyHydrology()
+
+	if world.groundCurr[0] != GroundWater || world.vegCurr[0] != VegetationNone || world.burnTTL[0] != 0 {
+		t.Fatalf("flooded tile should drown vegetation and fire, got %v/%v ttl %d", world.groundCurr[0], world.vegCurr[0], world.burnTTL[0])
+	}
+
+	world.waterDepth[0] = 0
+	world.waterDepth[1] = 0
+	for i := 0; i < 20; i++ {
+		world.applyHydrology()
+	}
+	if world.groundCurr[0] != GroundDirt {
+		t.Fatalf("dry lake bed should revert to dirt, got %v", world.groundCurr[0])
+	}
+}
+
+func TestWaterQuenchesLava(t *testing.T) {
+	world := newTestWorld(t, 3, 1, 3, stillWater)
+	world.groundCurr[0] = GroundWater
+	world.waterDepth[0] = 1
+	world.setLavaCell(1, 3, 1, 0, true)
+	world.setLavaCell(2, 3, 1, 0, true)
+	world.waterDepth[2] = 0.3
+	world.lavaElevation[0] = 5
+
+	world.applyHydrology()
+
+	if world.groundCurr[1] != GroundBasalt || world.lavaHeight[1] != 0 || world.lavaScar[1] == 0 {
+		t.Fatalf("lava touching water should quench into scarred basalt, got %v height %d", world.groundCurr[1], world.lavaHeight[1])
+	}
+	if world.basaltDepth[1] != 3 {
+		t.Fatalf("quenched lava should keep its whole column as basalt, got %d", world.basaltDepth[1])
+	}
+	if world.groundCurr[2] != GroundLava {
+		t.Fatalf("lava away from open water should keep flowing, got %v", world.groundCurr[2])
+	}
+	if world.waterDepth[2] != 0 {
+		t.Fatalf("water standing on lava should flash to steam, got depth %.3f", world.waterDepth[2])
+	}
+}
+
+func TestWaterBlocksFireSpread(t *testing.T) {
+	world := newTestWorld(t, 3, 1, 3, stillWater)
+	world.cfg.Params.FireSpreadChance = 1
+	world.cfg.Params.FireSoilDampen = 0
+	world.cfg.Params.FireRainExtinguishChance = 0
+	for i := range world.vegCurr {
+		world.vegCurr[i] = VegetationGrass
+	}
+	world.groundCurr[0] = GroundWater
+	world.burnTTL[1] = 3
+
+	world.applyFire()
+
+	if world.burnTTL[0] != 0 {
+		t.Fatalf("fire should not spread onto open water")
+	}
+	if world.burnTTL[2] == 0 {
+		t.Fatalf("fire should still spread over dry ground")
+	}
+}
@@ -0,0 +1,90 @@
+package ecology
+
+import (
+	"strconv"
+
+	"mad-ca/internal/core"
+)
+
+// String returns the human-readable ground type name.
+func (g Ground) String() string {
+	switch g {
+	case GroundDirt:
+		return "dirt"
+	case GroundRock:
+		return "rock"
+	case GroundMountain:
+		return "mountain"
+	case GroundLava:
+		return "lava"
+	case GroundWater:
+		return "water"
+	case GroundBasalt:
+		return "basalt"
+	case GroundSand:
+		return "sand"
+	case GroundSnow:
+		return "snow"
+	case GroundWetland:
+		return "wetland"
+	case GroundTundra:
+		return "tundra"
+	default:
+		return "ground(" + strconv.Itoa(int(g)) + ")"
+	}
+}
+
+// String returns the human-readable vegetation stage name.
+func (v Vegetation) String() string {
+	switch v {
+	case VegetationNone:
+		return "none"
+	case VegetationGrass:
+		return "grass"
+	case VegetationShrub:
+		return "shrub"
+	case VegetationTree:
+		return "tree"
+	default:
+		return "vegetation(" + strconv.Itoa(int(v)) + ")"
+	}
+}
+
+var lavaDirectionNames = [...]string{"E", "SE", "S", "SW", "W", "NW", "N", "NE"}
+
+// InspectCell reports the tile details that the exported fields cannot carry
+// as plain numbers: enum names, the lava heading, and the local wind vector.
+// Rows share their names with the matching field labels so the inspector can
+// substitute them for the raw values.
+func (w *World) InspectCell(x, y int) []core.NamedValue {
+	if w == nil || x < 0 || y < 0 || x >= w.w || y >= w.h {
+		return nil
+	}
+	idx := y*w.w + x
+
+	dir := "-"
+	if d := w.lavaDir[idx]; d >= 0 && int(d) < len(lavaDirectionNames) {
+		dir = lavaDirectionNames[d]
+	}
+	windX, windY := w.WindVectorAt(float64(x)+0.5, float64(y)+0.5)
+
+	agent := "-"
+	if i := int(w.agentCell[idx]) - 1; i >= 0 && i < len(w.agents) {
+		a := w.agents[i]
+		agent = a.Kind.String() + " (energy " + formatInspectFloat(a.Energy) + ", age " + strconv.Itoa(a.Age) + ")"
+	}
+
+	return []core.NamedValue{
+		{Name: "Ground", Value: w.groundCurr[idx].String()},
+		{Name: "Vegetation", Value: w.vegCurr[idx].String()},
+		{Name: "Lava dir", Value: dir},
+		{Name: "Climate zone", Value: w.climateZone[idx].String()},
+		{Name: "Flow history", Value: strconv.Itoa(int(w.basaltDepth[idx]))},
+		{Name: "Agent", Value: agent},
+		{Name: "Wind", Value: formatInspectFloat(windX) + ", " + formatInspectFloat(windY)},
+	}
+}
+
+func formatInspectFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 3, 64)
+}
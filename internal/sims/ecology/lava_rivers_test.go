@@ -192,8 +192,8 @@ func TestLavaCoolingCrustsAndSolidifies(t *testing.T) {
 		world.applyLava()
 	}
 
-	if world.groundCurr[0] != GroundRock {
-		t.Fatalf("expected lava to cool into rock, got %v", world.groundCurr[0])
+	if world.groundCurr[0] != GroundBasalt {
+		t.Fatalf("expected lava to cool into basalt, got %v", world.groundCurr[0])
 	}
 	if world.lavaHeight[0] != 0 {
 		t.Fatalf("expected lava thickness to clear, got %d", world.lavaHeight[0])
@@ -262,7 +262,7 @@ func TestLavaChannelReinforcement(t *testing.T) {
 	if world.groundCurr[2] != GroundLava {
 		t.Fatalf("expected forced tip to advance east, got %v", world.groundCurr[2])
 	}
-	expected := float32(lavaChannelGrow) * float32(1-lavaChannelDecay)
+	expected := float32(cfg.Params.LavaChannelGrow) * float32(1-cfg.Params.LavaChannelDecay)
 	if got := world.lavaChannel[2]; math.Abs(float64(got-expected)) > 1e-3 {
 		t.Fatalf("expected channel reinforcement %.5f, got %.5f", expected, got)
 	}
@@ -315,7 +315,7 @@ func TestLavaTipPoolsWhenBlocked(t *testing.T) {
 
 	world := NewWithConfig(cfg)
 	world.Reset(0)
-	world.rng.Seed(1)
+	world.streams.lava.Seed(1)
 
 	for i := range world.groundCurr {
 		world.groundCurr[i] = GroundRock
@@ -358,7 +358,7 @@ func TestLavaTipSplitsWhenFluxHigh(t *testing.T) {
 
 	world := NewWithConfig(cfg)
 	world.Reset(0)
-	world.rng.Seed(2)
+	world.streams.lava.Seed(2)
 
 	for i := range world.groundCurr {
 		world.groundCurr[i] = GroundRock
@@ -405,3 +405,128 @@ func TestLavaTipSplitsWhenFluxHigh(t *testing.T) {
 		t.Fatalf("expected parent channel to shed two units after spawning children, height=%d", world.lavaHeight[tipIdx])
 	}
 }
+
+// runLavaSlope feeds a lava source at the top of an eastward rock slope for
+// the given ticks and returns the world.
+func runLavaSlope(t *testing.T, cfg Config, ticks int) *World {
+	t.Helper()
+	cfg.Width = 24
+	cfg.Height = 9
+	cfg.Params.GrassPatchCount = 0
+	cfg.Params.RockChance = 0
+	cfg.Params.VolcanoProtoSpawnChance = 0
+
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+	world.streams.lava.Seed(3)
+	for i := range world.groundCurr {
+		world.groundCurr[i] = GroundRock
+		world.lavaElevation[i] = int16(100 - 4*(i%world.w))
+	}
+	maxHeight := world.lavaMaxHeight()
+	source := 4*world.w + 1
+	for tick := 0; tick < ticks; tick++ {
+		world.setLavaCell(source, maxHeight, 1, 0, true)
+		world.applyLava()
+		for idx, ground := range world.groundCurr {
+			height := int(world.lavaHeight[idx])
+			if ground == GroundLava && (height < 1 || height > maxHeight) {
+				t.Fatalf("tick %d: lava height %d at %d outside 1..%d", tick, height, idx, maxHeight)
+			}
+			if ground != GroundLava && height != 0 {
+				t.Fatalf("tick %d: non-lava tile %d kept height %d", tick, idx, height)
+			}
+			if temp := world.lavaTemp[idx]; temp < 0 || temp > 1 {
+				t.Fatalf("tick %d: lava temperature %.3f at %d outside 0..1", tick, temp, idx)
+			}
+			if channel := world.lavaChannel[idx]; channel < 0 || channel > 1 {
+				t.Fatalf("tick %d: channel %.3f at %d outside 0..1", tick, channel, idx)
+			}
+			if dir := world.lavaDir[idx]; dir < -1 || dir > 7 {
+				t.Fatalf("tick %d: lava direction %d at %d", tick, dir, idx)
+			}
+		}
+	}
+	return world
+}
+
+// lavaReach reports the easternmost column covered by lava.
+func lavaReach(world *World) int {
+	reach := -1
+	for idx, ground := range world.groundCurr {
+		if ground == GroundLava {
+			reach = max(reach, idx%world.w)
+		}
+	}
+	return reach
+}
+
+func TestLavaFlowStaysStableAtExtremeSettings(t *testing.T) {
+	extremes := map[string]map[string]string{
+		"thin and eager": {
+			"lava_max_height": "1", "lava_overflow_height": "1", "lava_split_min_height": "1",
+			"lava_split_chance": "1", "lava_base_speed": "4", "lava_speed_alpha": "0",
+			"lava_flow_threshold": "-8", "lava_split_threshold_drop": "0",
+			"lava_channel_grow": "1", "lava_channel_decay": "0",
+		},
+		"thick and stuck": {
+			"lava_max_height": "255", "lava_overflow_height": "255", "lava_base_speed": "0",
+			"lava_speed_alpha": "8", "lava_flow_threshold": "8", "lava_wall_weight": "8",
+			"lava_tip_temperature_min": "1", "lava_reheat_cap": "1",
+		},
+		"weightless": {
+			"lava_slope_weight": "0", "lava_align_weight": "0", "lava_channel_weight": "0",
+			"lava_rain_weight": "0", "lava_wall_weight": "0", "lava_flow_threshold": "0",
+			"lava_channel_decay": "1", "lava_tip_temperature_min": "0", "lava_reheat_cap": "0",
+		},
+		"clamped out of range": {
+			"lava_max_height": "9999", "lava_split_chance": "7", "lava_speed_alpha": "-3",
+			"lava_flow_threshold": "-100", "lava_channel_grow": "5",
+		},
+	}
+	for name, values := range extremes {
+		t.Run(name, func(t *testing.T) {
+			runLavaSlope(t, FromMap(values), 80)
+		})
+	}
+}
+
+func TestLavaViscosityShapesFlows(t *testing.T) {
+	// Without cooling and overflow pushes, only viscosity sets the pace.
+	flow := func(extra ...string) Config {
+		values := map[string]string{
+			"lava_cool_base": "0", "lava_cool_edge": "0", "lava_cool_thick": "0", "lava_cool_flux": "0",
+			"lava_overflow_height": "255",
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			values[extra[i]] = extra[i+1]
+		}
+		return FromMap(values)
+	}
+	pahoehoe := flow("lava_speed_alpha", "0", "lava_base_speed", "1")
+	aa := flow("lava_speed_alpha", "8", "lava_max_height", "20")
+
+	fluid := lavaReach(runLavaSlope(t, pahoehoe, 30))
+	viscous := lavaReach(runLavaSlope(t, aa, 30))
+	if fluid < viscous+4 {
+		t.Fatalf("expected runny lava to reach further than viscous lava, got %d vs %d", fluid, viscous)
+	}
+}
+
+func TestLavaFlowParamsAreValidated(t *testing.T) {
+	if _, err := ParseConfig(map[string]string{"lava_max_height": "300"}); err == nil {
+		t.Fatalf("expected strict parsing to reject heights beyond the uint8 buffers")
+	}
+	if _, err := ParseConfig(map[string]string{"lava_split_chance": "1.5"}); err == nil {
+		t.Fatalf("expected an out-of-range percent to be rejected")
+	}
+	cfg := FromMap(map[string]string{"lava_max_height": "300", "lava_speed_alpha": "-1"})
+	if cfg.Params.LavaMaxHeight != 255 || cfg.Params.LavaSpeedAlpha != 0 {
+		t.Fatalf("expected lenient parsing to clamp, got height %d alpha %v", cfg.Params.LavaMaxHeight, cfg.Params.LavaSpeedAlpha)
+	}
+	world := NewWithConfig(DefaultConfig())
+	world.cfg.Params.LavaMaxHeight = 0
+	if got := world.lavaMaxHeight(); got != 1 {
+		t.Fatalf("expected a zero max height to fall back to 1, got %d", got)
+	}
+}
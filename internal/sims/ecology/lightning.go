@@ -0,0 +1,94 @@
+package ecology
+
+import "math"
+
+// lightningEvents tallies lightning activity during one tick.
+type lightningEvents struct {
+	strikes   int
+	ignitions int
+}
+
+// applyLightning fades earlier flashes and lets every active rain region
+// throw at most one strike per tick. Each region strikes with
+// LightningChance scaled by its strength, multiplied by LightningSquallBoost
+// for squall lines. Strikes favour the ragged storm edge over the soaked
+// core, and a LightningDryChance share lands just beyond the rain footprint
+// as dry lightning, where no rain dampens the resulting fire.
+func (w *World) applyLightning() {
+	w.lightningEvents = lightningEvents{}
+	total := w.w * w.h
+	if total == 0 || len(w.lightningFlash) != total {
+		return
+	}
+	for i, flash := range w.lightningFlash {
+		if flash > 0 {
+			w.lightningFlash[i] = flash - 1
+		}
+	}
+
+	params := w.cfg.Params
+	if params.LightningChance <= 0 {
+		return
+	}
+	for i := range w.rainRegions {
+		region := &w.rainRegions[i]
+		chance := params.LightningChance * clamp01(region.strength)
+		if region.preset == rainPresetSquall {
+			chance *= params.LightningSquallBoost
+		}
+		if chance <= 0 || w.streams.fire.Float64() >= clamp01(chance) {
+			continue
+		}
+		if idx, ok := w.lightningTarget(region); ok {
+			w.strikeLightning(idx)
+		}
+	}
+}
+
+// lightningTarget picks the tile struck by region. The normalised radius is
+// drawn so strikes thin out toward the core as LightningEdgeBias rises; dry
+// strikes fall between 1 and 1.4 radii out.
+func (w *World) lightningTarget(region *rainRegion) (int, bool) {
+	params := &w.cfg.Params
+	angle := w.streams.fire.Float64() * 2 * math.Pi
+	var radial float64
+	if w.streams.fire.Float64() < params.LightningDryChance {
+		radial = 1 + 0.4*w.streams.fire.Float64()
+	} else {
+		// A square root spreads strikes evenly over the area; higher
+		// roots push them outward.
+		radial = math.Pow(w.streams.fire.Float64(), 1/(2+4*clamp01(params.LightningEdgeBias)))
+	}
+
+	rx := math.Cos(angle) * radial * region.radiusX
+	ry := math.Sin(angle) * radial * region.radiusY
+	cosA := math.Cos(region.angle)
+	sinA := math.Sin(region.angle)
+	x := int(math.Floor(region.cx + rx*cosA - ry*sinA))
+	y := int(math.Floor(region.cy + rx*sinA + ry*cosA))
+	return w.tileIndex(x, y)
+}
+
+// strikeLightning flashes idx and may ignite vegetation there. Ignition
+// follows the same soil and rain dampening as lava ignition, so strikes in
+// the storm core rarely catch while dry strikes readily do.
+func (w *World) strikeLightning(idx int) {
+	params := &w.cfg.Params
+	w.lightningEvents.strikes++
+	if params.LightningFlashTicks > 0 {
+		w.lightningFlash[idx] = uint8(min(params.LightningFlashTicks, 255))
+	}
+
+	if w.vegCurr[idx] == VegetationNone || w.groundCurr[idx] == GroundWater || w.burnTTL[idx] > 0 {
+		return
+	}
+	chance := params.LightningIgniteChance * w.soilFireFactor(idx)
+	if idx < len(w.rainCurr) && params.FireRainSpreadDampen > 0 {
+		chance *= clamp01(1 - params.FireRainSpreadDampen*float64(w.rainCurr[idx]))
+	}
+	if chance <= 0 || w.streams.fire.Float64() >= chance {
+		return
+	}
+	w.burnTTL[idx] = uint8(min(max(params.BurnTTL, 1), 255))
+	w.lightningEvents.ignitions++
+}
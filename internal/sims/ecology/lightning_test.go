@@ -0,0 +1,113 @@
+package ecology
+
+import (
+	"math"
+	"testing"
+)
+
+func stormAt(cx, cy, radius float64, preset rainPreset) rainRegion {
+	return rainRegion{cx: cx, cy: cy, radiusX: radius, radiusY: radius, strength: 1, ttl: 1000, maxTTL: 1000, preset: preset}
+}
+
+func TestSquallsStrikeMoreOftenThanShowers(t *testing.T) {
+	count := func(preset rainPreset) int {
+		world := newTestWorld(t, 64, 64, 23)
+		fillVegetation(world, VegetationGrass)
+		world.cfg.Params.LightningChance = 0.05
+		world.rainRegions = []rainRegion{stormAt(32, 32, 16, preset)}
+		strikes := 0
+		for tick := 0; tick < 2000; tick++ {
+			world.applyLightning()
+			strikes += world.EnvironmentSummary().LightningStrikes
+		}
+		return strikes
+	}
+	puffy, squall := count(rainPresetPuffy), count(rainPresetSquall)
+	if puffy == 0 || squall < 2*puffy {
+		t.Fatalf("squall lines should be far more electric: puffy %d squall %d", puffy, squall)
+	}
+}
+
+func TestLightningFavoursStormEdges(t *testing.T) {
+	meanRadial := func(bias float64) float64 {
+		world := newTestWorld(t, 128, 128, 23)
+		fillVegetation(world, VegetationGrass)
+		world.cfg.Params.LightningDryChance = 0
+		world.cfg.Params.LightningEdgeBias = bias
+		region := stormAt(64, 64, 40, rainPresetPuffy)
+		sum := 0.0
+		for n := 0; n < 4000; n++ {
+			idx, ok := world.lightningTarget(&region)
+			if !ok {
+				t.Fatalf("strike inside the world fell outside it")
+			}
+			x, y := float64(idx%world.w)+0.5, float64(idx/world.w)+0.5
+			sum += math.Hypot(x-region.cx, y-region.cy) / region.radiusX
+		}
+		return sum / 4000
+	}
+	if even, edge := meanRadial(0), meanRadial(1); edge <= even+0.05 {
+		t.Fatalf("edge bias should push strikes outward: even %.3f edge %.3f", even, edge)
+	}
+}
+
+func TestDryLightningIgnitesBeyondTheRain(t *testing.T) {
+	world := newTestWorld(t, 64, 64, 23)
+	fillVegetation(world, VegetationGrass)
+	world.cfg.Params.LightningChance = 1
+	world.cfg.Params.LightningIgniteChance = 1
+	world.cfg.Params.FireRainSpreadDampen = 1
+	world.rainRegions = []rainRegion{stormAt(32, 32, 12, rainPresetPuffy)}
+
+	// Soak the storm footprint and test wet strikes first.
+	for i := range world.rainCurr {
+		x, y := float64(i%world.w)+0.5, float64(i/world.w)+0.5
+		if math.Hypot(x-32, y-32) <= 13 {
+			world.rainCurr[i] = 1
+		}
+	}
+	world.cfg.Params.LightningDryChance = 0
+	for tick := 0; tick < 50; tick++ {
+		world.applyLightning()
+		if world.lightningEvents.ignitions > 0 {
+			t.Fatalf("strikes into a drenched storm core should not ignite")
+		}
+	}
+
+	world.cfg.Params.LightningDryChance = 1
+	fires := 0
+	for tick := 0; tick < 50; tick++ {
+		world.applyLightning()
+		fires += world.lightningEvents.ignitions
+	}
+	if fires == 0 {
+		t.Fatalf("dry lightning outside the rain should start fires")
+	}
+}
+
+func TestLightningFlashesDisplayAndHeat(t *testing.T) {
+	world := newTestWorld(t, 8, 8, 23)
+	fillVegetation(world, VegetationGrass)
+	world.cfg.Params.LightningFlashTicks = 2
+	world.cfg.Params.LightningIgniteChance = 0
+	world.strikeLightning(10)
+	world.rebuildDisplay()
+
+	if world.display[10] != displayFlash {
+		t.Fatalf("struck tile should flash on the display, got %#x", world.display[10])
+	}
+	if world.heatField[10] != 1 {
+		t.Fatalf("struck tile should peak in the heat field, got %.2f", world.heatField[10])
+	}
+	if world.EnvironmentSummary().LightningStrikes != 1 {
+		t.Fatalf("strike should be counted")
+	}
+
+	world.cfg.Params.LightningChance = 0
+	world.applyLightning()
+	world.applyLightning()
+	world.rebuildDisplay()
+	if world.display[10] == displayFlash || world.heatField[10] != 0 {
+		t.Fatalf("flash should fade after LightningFlashTicks ticks")
+	}
+}
@@ -0,0 +1,279 @@
+package ecology
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// Map layer file names written by ExportMaps. LoadConfig accepts the same
+// files through the ground_map, vegetation_map, elevation_map, and
+// tectonic_map keys.
+const (
+	GroundMapFile     = "ground.png"
+	VegetationMapFile = "vegetation.png"
+	ElevationMapFile  = "elevation.png"
+	TectonicMapFile   = "tectonic.png"
+)
+
+// mapLayers holds decoded map images sized to the world. Nil slices mean the
+// layer was not supplied and Reset generates it as usual.
+type mapLayers struct {
+	ground     []Ground
+	vegetation []Vegetation
+	elevation  []int16
+	tectonic   []float32
+}
+
+// LoadConfig parses cfg strictly like ParseConfig and then decodes any PNG
+// map layers it references. Map images must share one size. The world adopts that size
+// unless w or h is given explicitly, in which case a mismatch is an error.
+func LoadConfig(cfg map[string]string) (Config, error) {
+	c, err := ParseConfig(cfg)
+	if err != nil {
+		return c, err
+	}
+	if c.GroundMap == "" && c.VegetationMap == "" && c.ElevationMap == "" && c.TectonicMap == "" {
+		return c, nil
+	}
+
+	_, explicitW := cfg["w"]
+	_, explicitH := cfg["h"]
+	sized := explicitW || explicitH
+	layers := &mapLayers{}
+	var sizeFrom string
+
+	load := func(key, path string, decode func(img image.Image)) error {
+		if path == "" {
+			return nil
+		}
+		img, err := readPNG(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		bounds := img.Bounds()
+		if !sized {
+			c.Width, c.Height = bounds.Dx(), bounds.Dy()
+			sized = true
+			sizeFrom = key
+		}
+		if bounds.Dx() != c.Width || bounds.Dy() != c.Height {
+			want := "world"
+			if sizeFrom != "" {
+				want = sizeFrom
+			}
+			return fmt.Errorf("%s: image is %dx%d but %s is %dx%d", key, bounds.Dx(), bounds.Dy(), want, c.Width, c.Height)
+		}
+		decode(img)
+		return nil
+	}
+
+	if err := load("ground_map", c.GroundMap, func(img image.Image) {
+		layers.ground = decodeKeyed(img, groundKeys())
+	}); err != nil {
+		return c, err
+	}
+	if err := load("vegetation_map", c.VegetationMap, func(img image.Image) {
+		layers.vegetation = decodeKeyed(img, vegetationKeys())
+	}); err != nil {
+		return c, err
+	}
+	if err := load("elevation_map", c.ElevationMap, func(img image.Image) {
+		layers.elevation = decodeElevation(img)
+	}); err != nil {
+		return c, err
+	}
+	if err := load("tectonic_map", c.TectonicMap, func(img image.Image) {
+		layers.tectonic = decodeTectonic(img)
+	}); err != nil {
+		return c, err
+	}
+
+	c.maps = layers
+	return c, nil
+}
+
+// applyMapLayers overwrites the freshly generated world with any loaded map
+// layers and reports which of ground and vegetation were supplied so Reset
+// can skip random seeding for them.
+func (w *World) applyMapLayers() (ground, vegetation bool) {
+	layers := w.cfg.maps
+	total := w.w * w.h
+	if layers == nil {
+		return false, false
+	}
+
+	if len(layers.elevation) == total {
+		copy(w.terrainElevation, layers.elevation)
+		copy(w.lavaElevation, layers.elevation)
+	}
+	if len(layers.tectonic) == total {
+		copy(w.tectonic, layers.tectonic)
+	}
+	if len(layers.ground) == total {
+		ground = true
+		for i, g := range layers.ground {
+			w.waterDepth[i] = 0
+			switch g {
+			case GroundLava:
+				w.setLavaCell(i, 1, 1, -1, false)
+				continue
+			case GroundWater:
+				w.waterDepth[i] = float32(w.cfg.Params.WaterLakeDepth)
+				w.soilMoisture[i] = 1
+			}
+			w.groundCurr[i] = g
+		}
+	}
+	if len(layers.vegetation) == total {
+		vegetation = true
+		for i, v := range layers.vegetation {
+			if g := w.groundCurr[i]; g == GroundLava || g == GroundWater {
+				v = VegetationNone
+			}
+			w.vegCurr[i] = v
+		}
+	}
+	return ground, vegetation
+}
+
+// ExportMaps writes the ground, vegetation, elevation, and tectonic layers as
+// PNG files into dir so a run's state can be edited and reloaded through
+// LoadConfig.
+func (w *World) ExportMaps(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	rect := image.Rect(0, 0, w.w, w.h)
+
+	ground := image.NewNRGBA(rect)
+	vegetation := image.NewNRGBA(rect)
+	elevation := image.NewGray16(rect)
+	tectonic := image.NewGray16(rect)
+	for y := 0; y < w.h; y++ {
+		for x := 0; x < w.w; x++ {
+			idx := y*w.w + x
+			ground.SetNRGBA(x, y, paletteColorFor(w.groundCurr[idx], VegetationNone, false))
+			vegetation.SetNRGBA(x, y, vegetationColor(w.vegCurr[idx]))
+			elevation.SetGray16(x, y, color.Gray16{Y: uint16(max(w.lavaElevation[idx], 0))})
+			if idx < len(w.tectonic) {
+				tectonic.SetGray16(x, y, color.Gray16{Y: uint16(math.Round(clamp01(float64(w.tectonic[idx])) * math.MaxUint16))})
+			}
+		}
+	}
+
+	for name, img := range map[string]image.Image{
+		GroundMapFile:     ground,
+		VegetationMapFile: vegetation,
+		ElevationMapFile:  elevation,
+		TectonicMapFile:   tectonic,
+	} {
+		if err := writePNG(filepath.Join(dir, name), img); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return png.Decode(file)
+}
+
+func writePNG(path string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+type colorKey[T any] struct {
+	color color.NRGBA
+	value T
+}
+
+func groundKeys() []colorKey[Ground] {
+	grounds := []Ground{GroundDirt, GroundRock, GroundMountain, GroundLava, GroundWater, GroundBasalt, GroundSand, GroundSnow, GroundWetland, GroundTundra}
+	keys := make([]colorKey[Ground], len(grounds))
+	for i, g := range grounds {
+		keys[i] = colorKey[Ground]{color: paletteColorFor(g, VegetationNone, false), value: g}
+	}
+	return keys
+}
+
+func vegetationKeys() []colorKey[Vegetation] {
+	vegs := []Vegetation{VegetationNone, VegetationGrass, VegetationShrub, VegetationTree}
+	keys := make([]colorKey[Vegetation], len(vegs))
+	for i, v := range vegs {
+		keys[i] = colorKey[Vegetation]{color: vegetationColor(v), value: v}
+	}
+	return keys
+}
+
+// decodeKeyed maps every pixel to the value whose key colour is nearest in
+// RGB, so hand-painted maps tolerate anti-aliasing and slight colour drift.
+// Transparent pixels take the first key.
+func decodeKeyed[T any](img image.Image, keys []colorKey[T]) []T {
+	bounds := img.Bounds()
+	out := make([]T, bounds.Dx()*bounds.Dy())
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			c := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+			best := 0
+			if c.A >= 128 {
+				bestDist := math.MaxInt
+				for k, key := range keys {
+					dr := int(c.R) - int(key.color.R)
+					dg := int(c.G) - int(key.color.G)
+					db := int(c.B) - int(key.color.B)
+					if d := dr*dr + dg*dg + db*db; d < bestDist {
+						best, bestDist = k, d
+					}
+				}
+			}
+			out[y*bounds.Dx()+x] = keys[best].value
+		}
+	}
+	return out
+}
+
+// decodeElevation reads 16-bit grayscale as elevation units, clamped to the
+// int16 range. 8-bit images are widened by the decoder, so prefer 16-bit
+// files for anything beyond coarse relief.
+func decodeElevation(img image.Image) []int16 {
+	bounds := img.Bounds()
+	out := make([]int16, bounds.Dx()*bounds.Dy())
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			g := color.Gray16Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray16)
+			out[y*bounds.Dx()+x] = int16(min(int(g.Y), math.MaxInt16))
+		}
+	}
+	return out
+}
+
+// decodeTectonic reads grayscale as the 0–1 tectonic baseline.
+func decodeTectonic(img image.Image) []float32 {
+	bounds := img.Bounds()
+	out := make([]float32, bounds.Dx()*bounds.Dy())
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			g := color.Gray16Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray16)
+			out[y*bounds.Dx()+x] = float32(g.Y) / math.MaxUint16
+		}
+	}
+	return out
+}
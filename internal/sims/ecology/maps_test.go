@@ -0,0 +1,122 @@
+package ecology
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportedMapsReloadIntoTheSameWorld(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Width = 48
+	cfg.Height = 32
+	cfg.Seed = 11
+	cfg.Terrain = TerrainNoise
+	original := NewWithConfig(cfg)
+	original.Reset(0)
+
+	dir := t.TempDir()
+	if err := original.ExportMaps(dir); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	loaded, err := LoadConfig(map[string]string{
+		"ground_map":     filepath.Join(dir, GroundMapFile),
+		"vegetation_map": filepath.Join(dir, VegetationMapFile),
+		"elevation_map":  filepath.Join(dir, ElevationMapFile),
+		"tectonic_map":   filepath.Join(dir, TectonicMapFile),
+		"seed":           "99",
+	})
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if loaded.Width != 48 || loaded.Height != 32 {
+		t.Fatalf("world should adopt the map size, got %dx%d", loaded.Width, loaded.Height)
+	}
+	world := NewWithConfig(loaded)
+	world.Reset(0)
+
+	if !reflect.DeepEqual(world.groundCurr, original.groundCurr) {
+		t.Fatalf("ground layer did not round-trip")
+	}
+	if !reflect.DeepEqual(world.vegCurr, original.vegCurr) {
+		t.Fatalf("vegetation layer did not round-trip")
+	}
+	if !reflect.DeepEqual(world.lavaElevation, original.lavaElevation) || !reflect.DeepEqual(world.terrainElevation, original.lavaElevation) {
+		t.Fatalf("elevation layer did not round-trip")
+	}
+	for i := range world.tectonic {
+		if math.Abs(float64(world.tectonic[i]-original.tectonic[i])) > 1.0/65535 {
+			t.Fatalf("tectonic value %d drifted: %.6f vs %.6f", i, world.tectonic[i], original.tectonic[i])
+		}
+	}
+}
+
+func TestHandPaintedMapsUseNearestKeyColour(t *testing.T) {
+	dir := t.TempDir()
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	img.SetNRGBA(0, 0, color.NRGBA{R: 75, G: 50, B: 30, A: 255})   // off-dirt
+	img.SetNRGBA(1, 0, color.NRGBA{R: 250, G: 95, B: 45, A: 255})  // off-lava
+	img.SetNRGBA(2, 0, color.NRGBA{R: 35, G: 100, B: 180, A: 255}) // off-water
+	img.SetNRGBA(3, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 0})  // transparent
+	path := filepath.Join(dir, "ground.png")
+	if err := writePNG(path, img); err != nil {
+		t.Fatal(err)
+	}
+	veg := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	for x := 0; x < 4; x++ {
+		veg.SetNRGBA(x, 0, color.NRGBA{R: 72, G: 158, B: 84, A: 255})
+	}
+	vegPath := filepath.Join(dir, "veg.png")
+	if err := writePNG(vegPath, veg); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(map[string]string{"ground_map": path, "vegetation_map": vegPath})
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+
+	want := []Ground{GroundDirt, GroundLava, GroundWater, GroundDirt}
+	if !reflect.DeepEqual(world.groundCurr, want) {
+		t.Fatalf("expected %v, got %v", want, world.groundCurr)
+	}
+	if world.lavaHeight[1] == 0 || world.waterDepth[2] < float32(cfg.Params.WaterLakeDepth) {
+		t.Fatalf("painted lava and water should carry lava height and lake depth")
+	}
+	wantVeg := []Vegetation{VegetationGrass, VegetationNone, VegetationNone, VegetationGrass}
+	if !reflect.DeepEqual(world.vegCurr, wantVeg) {
+		t.Fatalf("expected vegetation %v, got %v", wantVeg, world.vegCurr)
+	}
+}
+
+func TestLoadConfigRejectsMismatchedMaps(t *testing.T) {
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.png")
+	large := filepath.Join(dir, "large.png")
+	if err := writePNG(small, image.NewGray16(image.Rect(0, 0, 4, 3))); err != nil {
+		t.Fatal(err)
+	}
+	if err := writePNG(large, image.NewGray16(image.Rect(0, 0, 8, 3))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadConfig(map[string]string{"elevation_map": small, "w": "8", "h": "3"}); err == nil || !strings.Contains(err.Error(), "elevation_map") {
+		t.Fatalf("expected a size error naming the map key, got %v", err)
+	}
+	if _, err := LoadConfig(map[string]string{"elevation_map": small, "tectonic_map": large}); err == nil {
+		t.Fatalf("expected maps of different sizes to be rejected")
+	}
+	if _, err := LoadConfig(map[string]string{"ground_map": filepath.Join(dir, "missing.png")}); err == nil {
+		t.Fatalf("expected a missing map file to fail")
+	}
+	if cfg, err := LoadConfig(map[string]string{"elevation_map": small, "w": "4", "h": "3"}); err != nil || cfg.Width != 4 {
+		t.Fatalf("matching explicit size should load, got %v", err)
+	}
+}
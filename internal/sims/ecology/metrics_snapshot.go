@@ -0,0 +1,51 @@
+package ecology
+
+import "mad-ca/internal/core"
+
+// MetricsSnapshot reports vegetation and environment telemetry for charting.
+func (w *World) MetricsSnapshot() core.MetricsSnapshot {
+	veg := w.vegetationMetrics()
+	env := w.EnvironmentSummary()
+	pop := w.population
+
+	rainCoverage, waterCoverage := 0.0, 0.0
+	if env.TotalTiles > 0 {
+		rainCoverage = float64(env.RainCoverage) / float64(env.TotalTiles) * 100
+		waterCoverage = float64(env.WaterTiles) / float64(env.TotalTiles) * 100
+	}
+
+	snapshot := core.MetricsSnapshot{
+		Samples: []core.MetricSample{
+			{Key: "grass_tiles", Label: "Grass", Group: "Vegetation", Value: float64(veg.GrassTiles)},
+			{Key: "shrub_tiles", Label: "Shrub", Group: "Vegetation", Value: float64(veg.ShrubTiles)},
+			{Key: "tree_tiles", Label: "Tree", Group: "Vegetation", Value: float64(veg.TreeTiles)},
+			{Key: "veg_deaths", Label: "Deaths", Group: "Turnover", Value: float64(veg.DroughtDeaths + veg.CrowdingDeaths + veg.AgeDeaths)},
+			{Key: "veg_seedlings", Label: "Seedlings", Group: "Turnover", Value: float64(veg.SeedlingsEstablished)},
+			{Key: "herbivores", Label: "Herbivores", Group: "Population", Value: float64(pop.Herbivores)},
+			{Key: "predators", Label: "Predators", Group: "Population", Value: float64(pop.Predators)},
+			{Key: "lava_tiles", Label: "Lava", Group: "Disturbance", Value: float64(env.LavaTiles)},
+			{Key: "basalt_tiles", Label: "Basalt", Group: "Disturbance", Value: float64(env.BasaltTiles)},
+			{Key: "burning_tiles", Label: "Burning", Group: "Disturbance", Value: float64(env.BurningTiles)},
+			{Key: "lightning_strikes", Label: "Lightning", Group: "Disturbance", Value: float64(env.LightningStrikes)},
+			{Key: "sand_tiles", Label: "Sand", Group: "Biomes", Value: float64(env.SandTiles)},
+			{Key: "snow_tiles", Label: "Snow", Group: "Biomes", Value: float64(env.SnowTiles)},
+			{Key: "wetland_tiles", Label: "Wetland", Group: "Biomes", Value: float64(env.WetlandTiles)},
+			{Key: "tundra_tiles", Label: "Tundra", Group: "Biomes", Value: float64(env.TundraTiles)},
+			{Key: "rain_coverage_pct", Label: "Coverage %", Group: "Rain", Value: rainCoverage},
+			{Key: "rain_regions", Label: "Regions", Group: "Rain", Value: float64(env.ActiveRainRegions)},
+			{Key: "weather_fronts", Label: "Fronts", Group: "Rain", Value: float64(env.WeatherFronts)},
+			{Key: "water_coverage_pct", Label: "Open water %", Group: "Rain", Value: waterCoverage},
+			{Key: "eroded", Label: "Eroded", Group: "Erosion", Value: env.Eroded},
+			{Key: "deposited", Label: "Deposited", Group: "Erosion", Value: env.Deposited},
+			{Key: "soil_moisture_mean", Label: "Mean", Group: "Soil moisture", Value: env.SoilMoistureMean},
+		},
+	}
+	if len(veg.ClusterHistogram) > 0 {
+		snapshot.Histograms = append(snapshot.Histograms, core.MetricHistogram{
+			Key:   "vegetation_clusters",
+			Label: "Vegetation cluster sizes",
+			Bins:  append([]int(nil), veg.ClusterHistogram...),
+		})
+	}
+	return snapshot
+}
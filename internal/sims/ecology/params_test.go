@@ -0,0 +1,141 @@
+package ecology
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mad-ca/internal/core"
+)
+
+func TestEveryParamIsDeclaredInTheSchema(t *testing.T) {
+	typ := reflect.TypeOf(Params{})
+	for i := 0; i < typ.NumField(); i++ {
+		if _, ok := typ.Field(i).Tag.Lookup("param"); !ok {
+			t.Fatalf("Params.%s has no param tag", typ.Field(i).Name)
+		}
+	}
+
+	world := NewWithConfig(DefaultConfig())
+	values := map[string]string{}
+	for _, group := range world.Parameters().Groups {
+		for _, param := range group.Params {
+			values[param.Key] = param.Value
+		}
+	}
+	for _, spec := range paramSchema.Specs() {
+		if _, ok := values[spec.Key]; !ok {
+			t.Fatalf("%s is missing from the parameter snapshot", spec.Key)
+		}
+	}
+	if err := paramSchema.Validate(DefaultConfig().Params); err != nil {
+		t.Fatalf("defaults should satisfy the schema: %v", err)
+	}
+	cfg, err := ParseConfig(values)
+	if err != nil {
+		t.Fatalf("snapshot values should parse strictly: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Params, DefaultConfig().Params) {
+		t.Fatalf("snapshot did not round-trip through ParseConfig")
+	}
+}
+
+func TestParseConfigReportsBadValuesThatFromMapSkips(t *testing.T) {
+	input := map[string]string{
+		"fire_spread_chance":  "often",
+		"terrain_plate_count": "1",
+		"fire_spred_chance":   "0.5",
+		"terrain":             "volcanic",
+	}
+	_, err := ParseConfig(input)
+	if err == nil {
+		t.Fatalf("expected strict parsing to fail")
+	}
+	for _, key := range []string{"fire_spread_chance", "terrain_plate_count", "fire_spred_chance", "terrain"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Fatalf("error should name %s, got %v", key, err)
+		}
+	}
+
+	lenient := FromMap(input)
+	if !reflect.DeepEqual(lenient.Params, DefaultConfig().Params) || lenient.Terrain != TerrainFlat {
+		t.Fatalf("FromMap should skip invalid values and keep defaults")
+	}
+}
+
+func TestFromMapClampsAndEnforcesFloors(t *testing.T) {
+	cfg := FromMap(map[string]string{
+		"climate_rain_amplitude": "3",
+		"fire_spread_chance":     "1.5",
+		"rain_radius_min":        "50",
+		"rain_radius_max":        "10",
+	})
+	if cfg.Params.ClimateRainAmplitude != 1 || cfg.Params.FireSpreadChance != 1 {
+		t.Fatalf("clamped tunables should saturate, got %v and %v", cfg.Params.ClimateRainAmplitude, cfg.Params.FireSpreadChance)
+	}
+	if cfg.Params.RainRadiusMax != 50 {
+		t.Fatalf("rain radius max should be raised to its min, got %d", cfg.Params.RainRadiusMax)
+	}
+	if _, err := ParseConfig(map[string]string{"rain_radius_min": "50", "rain_radius_max": "10"}); err == nil || !strings.Contains(err.Error(), "rain_radius_max") {
+		t.Fatalf("strict parsing should reject a max below its min, got %v", err)
+	}
+}
+
+func TestHUDControlsAndSettersShareTheSchema(t *testing.T) {
+	world := NewWithConfig(DefaultConfig())
+	controls := world.ParameterControls()
+	if len(controls) == 0 {
+		t.Fatalf("expected HUD controls")
+	}
+	for _, ctrl := range controls {
+		if ctrl.Key == "preset" {
+			// The preset selector is a config key, not a tunable.
+			continue
+		}
+		spec, ok := paramSchema.Spec(ctrl.Key)
+		if !ok {
+			t.Fatalf("control %s is not in the schema", ctrl.Key)
+		}
+		if ctrl.Percent != spec.Percent || (ctrl.Percent && ctrl.Max != 100) {
+			t.Fatalf("control %s should present percent tunables on 0–100", ctrl.Key)
+		}
+		switch ctrl.Type {
+		case core.ParamTypeInt:
+			if !world.SetIntParameter(ctrl.Key, int(ctrl.Min)) {
+				t.Fatalf("int control %s is not settable", ctrl.Key)
+			}
+		case core.ParamTypeFloat:
+			if !world.SetFloatParameter(ctrl.Key, ctrl.Min) {
+				t.Fatalf("float control %s is not settable", ctrl.Key)
+			}
+		}
+	}
+
+	if !world.SetFloatParameter("tree_growth_chance", 25) || math.Abs(world.cfg.Params.TreeGrowthChance-0.25) > 1e-9 {
+		t.Fatalf("percent setters should take 0–100, got %v", world.cfg.Params.TreeGrowthChance)
+	}
+	if world.SetFloatParameter("burn_ttl", 3) || world.SetIntParameter("no_such_key", 1) {
+		t.Fatalf("setters should reject mismatched types and unknown keys")
+	}
+}
+
+func TestSettersKeepMinMaxPairsOrdered(t *testing.T) {
+	world := NewWithConfig(DefaultConfig())
+	params := &world.cfg.Params
+
+	world.SetFloatParameter("rain_strength_max", 0)
+	if params.RainStrengthMax != params.RainStrengthMin {
+		t.Fatalf("max should not drop below min, got %v < %v", params.RainStrengthMax, params.RainStrengthMin)
+	}
+	world.SetFloatParameter("volcano_proto_strength_min", 1)
+	if params.VolcanoProtoStrengthMin != params.VolcanoProtoStrengthMax {
+		t.Fatalf("min should not rise above max, got %v > %v", params.VolcanoProtoStrengthMin, params.VolcanoProtoStrengthMax)
+	}
+	world.climateTick = 100
+	world.updateClimate()
+	world.SetIntParameter("season_length", 100)
+	if world.Climate().Season != SeasonSummer {
+		t.Fatalf("climate should refresh after a climate setter, got %v", world.Climate().Season)
+	}
+}
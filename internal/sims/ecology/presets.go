@@ -0,0 +1,167 @@
+package ecology
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultPreset names the built-in parameter pack, DefaultConfig itself.
+const DefaultPreset = "default"
+
+//go:embed presets/*.json
+var presetFiles embed.FS
+
+// presetFile is the on-disk preset format: a description plus config keys
+// that overlay DefaultConfig. Values may be JSON strings, numbers, or bools.
+type presetFile struct {
+	Description string         `json:"description"`
+	Params      map[string]any `json:"params"`
+}
+
+// PresetNames lists DefaultPreset followed by the built-in presets in
+// alphabetical order.
+func PresetNames() []string {
+	names := []string{DefaultPreset}
+	entries, _ := presetFiles.ReadDir("presets")
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// loadPreset resolves name to a built-in preset or, failing that, a preset
+// file path, and returns its config keys. Every key and value is checked
+// strictly, so a preset with an unknown key or out-of-range value fails
+// instead of silently falling back to defaults.
+func loadPreset(name string) (map[string]string, error) {
+	if name == "" || name == DefaultPreset {
+		return map[string]string{}, nil
+	}
+	data, err := presetFiles.ReadFile(path.Join("presets", name+".json"))
+	if err != nil {
+		data, err = os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("preset %q: not a built-in preset (%s) or readable file", name, strings.Join(PresetNames(), ", "))
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	var file presetFile
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("preset %q: %w", name, err)
+	}
+	values := make(map[string]string, len(file.Params))
+	for key, raw := range file.Params {
+		switch v := raw.(type) {
+		case string:
+			values[key] = v
+		case json.Number:
+			values[key] = v.String()
+		case bool:
+			values[key] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("preset %q: %s must be a string, number, or bool", name, key)
+		}
+	}
+	if _, nested := values["preset"]; nested {
+		return nil, fmt.Errorf("preset %q: presets cannot include other presets", name)
+	}
+	if _, err := parseKeys(values, true); err != nil {
+		return nil, fmt.Errorf("preset %q: %w", name, err)
+	}
+	return values, nil
+}
+
+// withPreset overlays cfg on the preset it names, so explicit keys win over
+// the preset and the preset wins over DefaultConfig.
+func withPreset(cfg map[string]string) (map[string]string, error) {
+	name, ok := cfg["preset"]
+	if !ok {
+		return cfg, nil
+	}
+	values, err := loadPreset(name)
+	if err != nil {
+		return cfg, err
+	}
+	for key, v := range cfg {
+		values[key] = v
+	}
+	return values, nil
+}
+
+// SetStringParameter switches the live world to a preset picked on the HUD.
+// The world keeps its size, map files, and the seed of its last Reset, and is
+// reset so terrain settings take effect.
+func (w *World) SetStringParameter(key, value string) bool {
+	if w == nil || key != "preset" {
+		return false
+	}
+	values, err := loadPreset(value)
+	if err != nil {
+		return false
+	}
+	cfg := FromMap(values)
+	w.cfg.Params = cfg.Params
+	w.cfg.Terrain = cfg.Terrain
+	w.cfg.Topology = cfg.Topology
+	w.cfg.Preset = value
+	w.Reset(w.seed)
+	return true
+}
+
+// SavePreset writes the live parameters that differ from DefaultConfig as a
+// preset file, so HUD tweaks can be kept and loaded again with preset=PATH.
+func (w *World) SavePreset(file string) error {
+	defaults := DefaultConfig()
+	current := map[string]string{}
+	base := map[string]string{}
+	for _, group := range paramSchema.Groups(w.cfg.Params) {
+		for _, param := range group.Params {
+			current[param.Key] = param.Value
+		}
+	}
+	for _, group := range paramSchema.Groups(defaults.Params) {
+		for _, param := range group.Params {
+			base[param.Key] = param.Value
+		}
+	}
+
+	preset := presetFile{Params: map[string]any{}}
+	description := "Saved live parameters"
+	if w.cfg.Preset != "" && w.cfg.Preset != DefaultPreset {
+		description += " based on " + w.cfg.Preset
+	}
+	preset.Description = description + "."
+	if w.cfg.Terrain != defaults.Terrain {
+		preset.Params["terrain"] = w.cfg.Terrain
+	}
+	if w.cfg.Topology != defaults.Topology {
+		preset.Params["topology"] = w.cfg.Topology
+	}
+	for key, value := range current {
+		if value == base[key] {
+			continue
+		}
+		if json.Valid([]byte(value)) {
+			preset.Params[key] = json.RawMessage(value)
+		} else {
+			preset.Params[key] = value
+		}
+	}
+
+	data, err := json.MarshalIndent(preset, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,115 @@
+package ecology
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuiltInPresetsParseStrictly(t *testing.T) {
+	names := PresetNames()
+	if len(names) < 2 || names[0] != DefaultPreset {
+		t.Fatalf("expected the default preset followed by built-ins, got %v", names)
+	}
+	for _, name := range names {
+		cfg, err := ParseConfig(map[string]string{"preset": name})
+		if err != nil {
+			t.Fatalf("preset %s should parse: %v", name, err)
+		}
+		if name != DefaultPreset && reflect.DeepEqual(cfg.Params, DefaultConfig().Params) && cfg.Terrain == DefaultConfig().Terrain {
+			t.Fatalf("preset %s changes nothing", name)
+		}
+	}
+}
+
+func TestPresetOverlaysDefaultsBeneathExplicitKeys(t *testing.T) {
+	keys := map[string]string{"preset": "volcanic_island", "terrain_relief": "12"}
+	cfg, err := ParseConfig(keys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lenient := FromMap(keys); lenient != cfg {
+		t.Fatalf("strict and lenient parsing disagree:\n%+v\n%+v", cfg, lenient)
+	}
+	if cfg.Terrain != TerrainNoise {
+		t.Fatalf("expected the preset terrain, got %q", cfg.Terrain)
+	}
+	if cfg.Params.TerrainRelief != 12 {
+		t.Fatalf("explicit terrain_relief should win over the preset, got %d", cfg.Params.TerrainRelief)
+	}
+	if cfg.Params.LavaReservoirMax != 300 {
+		t.Fatalf("expected the preset lava reservoir, got %d", cfg.Params.LavaReservoirMax)
+	}
+	if cfg.Preset != "volcanic_island" {
+		t.Fatalf("expected the preset name to be recorded, got %q", cfg.Preset)
+	}
+}
+
+func TestPresetWithUnknownKeyFailsLoudly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.json")
+	data := `{"description": "typo", "params": {"fire_sprad_chance": 0.5}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write preset: %v", err)
+	}
+	_, err := ParseConfig(map[string]string{"preset": path})
+	if err == nil || !strings.Contains(err.Error(), "fire_sprad_chance") {
+		t.Fatalf("expected the unknown key to be reported, got %v", err)
+	}
+	if _, err := ParseConfig(map[string]string{"preset": "no_such_preset"}); err == nil {
+		t.Fatalf("expected an unknown preset name to fail")
+	}
+	if cfg := FromMap(map[string]string{"preset": path}); !reflect.DeepEqual(cfg.Params, DefaultConfig().Params) {
+		t.Fatalf("lenient FromMap should ignore a broken preset")
+	}
+}
+
+func TestSavePresetRoundTripsLiveParameters(t *testing.T) {
+	world := NewWithConfig(FromMap(map[string]string{"w": "16", "h": "16", "preset": "stormy"}))
+	if !world.SetFloatParameter("fire_spread_chance", 55) {
+		t.Fatalf("expected fire_spread_chance to be adjustable")
+	}
+	path := filepath.Join(t.TempDir(), "saved.json")
+	if err := world.SavePreset(path); err != nil {
+		t.Fatalf("save preset: %v", err)
+	}
+
+	cfg, err := ParseConfig(map[string]string{"preset": path})
+	if err != nil {
+		t.Fatalf("saved preset should parse strictly: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Params, world.cfg.Params) {
+		t.Fatalf("saved preset did not round-trip the live parameters")
+	}
+	if cfg.Params.FireSpreadChance != 0.55 {
+		t.Fatalf("expected the HUD tweak to be saved, got %v", cfg.Params.FireSpreadChance)
+	}
+}
+
+func TestSetStringParameterSwitchesPreset(t *testing.T) {
+	world := NewWithConfig(FromMap(map[string]string{"w": "16", "h": "16", "seed": "9"}))
+	if !world.SetStringParameter("preset", "wet_forest") {
+		t.Fatalf("expected the wet_forest preset to apply")
+	}
+	want := FromMap(map[string]string{"preset": "wet_forest"}).Params
+	if !reflect.DeepEqual(world.cfg.Params, want) {
+		t.Fatalf("live parameters do not match the preset")
+	}
+	if world.cfg.Width != 16 || world.cfg.Seed != 9 {
+		t.Fatalf("switching presets should keep the world size and seed")
+	}
+	world.Reset(77)
+	fresh := NewWithConfig(FromMap(map[string]string{"w": "16", "h": "16", "preset": "stormy"}))
+	fresh.Reset(77)
+	if !world.SetStringParameter("preset", "stormy") || !slices.Equal(world.groundCurr, fresh.groundCurr) || !slices.Equal(world.vegCurr, fresh.vegCurr) {
+		t.Fatalf("switching presets should reset under the seed of the last Reset")
+	}
+	if world.SetStringParameter("preset", "no_such_preset") {
+		t.Fatalf("unknown presets should be rejected")
+	}
+	if world.presetName() != "stormy" {
+		t.Fatalf("a rejected preset should leave the current one active, got %q", world.presetName())
+	}
+}
@@ -0,0 +1,112 @@
+package ecology
+
+import (
+	"time"
+
+	"mad-ca/internal/core"
+)
+
+// stepPhase identifies one timed phase of Step.
+type stepPhase int
+
+const (
+	phaseClimate stepPhase = iota
+	phaseRain
+	phaseVolcano
+	phaseUplift
+	phaseEruptions
+	phaseLava
+	phaseHydrology
+	phaseErosion
+	phaseLightning
+	phaseFire
+	phaseAgents
+	phaseSuccession
+	phaseMetrics
+	phaseSoil
+	phaseSpawning
+	phaseDisplay
+	phaseCount
+)
+
+var stepPhaseNames = [phaseCount]string{
+	phaseClimate:    "climate",
+	phaseRain:       "rain mask",
+	phaseVolcano:    "volcano mask",
+	phaseUplift:     "uplift",
+	phaseEruptions:  "eruptions",
+	phaseLava:       "lava",
+	phaseHydrology:  "hydrology",
+	phaseErosion:    "erosion",
+	phaseLightning:  "lightning",
+	phaseFire:       "fire",
+	phaseAgents:     "agents",
+	phaseSuccession: "succession",
+	phaseMetrics:    "metrics",
+	phaseSoil:       "soil",
+	phaseSpawning:   "spawning",
+	phaseDisplay:    "display",
+}
+
+// profileWindow is the number of ticks the rolling phase averages cover.
+const profileWindow = 60
+
+// stepProfile times the phases of Step. Each tick fills one slot of a ring
+// of profileWindow ticks; sums tracks the ring's per-phase totals so the
+// rolling averages cost nothing to read.
+type stepProfile struct {
+	samples [profileWindow][phaseCount]time.Duration
+	sums    [phaseCount]time.Duration
+	totals  [phaseCount]time.Duration
+	ticks   int
+	slot    int
+	mark    time.Time
+}
+
+// startTick opens a new ring slot, dropping the oldest tick from the rolling
+// sums, and starts the clock for the first phase.
+func (p *stepProfile) startTick() {
+	p.slot = p.ticks % profileWindow
+	p.ticks++
+	for phase, d := range p.samples[p.slot] {
+		p.sums[phase] -= d
+	}
+	p.samples[p.slot] = [phaseCount]time.Duration{}
+	p.mark = time.Now()
+}
+
+// lap charges the time since the previous lap to phase.
+func (p *stepProfile) lap(phase stepPhase) {
+	now := time.Now()
+	p.add(phase, now.Sub(p.mark))
+	p.mark = now
+}
+
+// add charges d to phase in the current tick. Work done between ticks on
+// the tick's behalf, such as labelling clusters when metrics are read,
+// lands in the tick that produced the state.
+func (p *stepProfile) add(phase stepPhase, d time.Duration) {
+	if p.ticks == 0 {
+		return
+	}
+	p.samples[p.slot][phase] += d
+	p.sums[phase] += d
+	p.totals[phase] += d
+}
+
+// PhaseTimings reports the time spent in each phase of Step, in step order.
+// Averages cover the last profileWindow ticks; totals run since Reset.
+func (w *World) PhaseTimings() []core.PhaseTiming {
+	p := &w.profile
+	window := min(p.ticks, profileWindow)
+	timings := make([]core.PhaseTiming, phaseCount)
+	for phase := range timings {
+		timing := core.PhaseTiming{Name: stepPhaseNames[phase], Total: p.totals[phase]}
+		if window > 0 {
+			timing.Last = p.samples[p.slot][phase]
+			timing.Average = p.sums[phase] / time.Duration(window)
+		}
+		timings[phase] = timing
+	}
+	return timings
+}
@@ -0,0 +1,66 @@
+package ecology
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPhaseTimingsCoverStep(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Width = 32
+	cfg.Height = 32
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+
+	for _, timing := range world.PhaseTimings() {
+		if timing.Total != 0 || timing.Average != 0 {
+			t.Fatalf("expected no timings before the first step, got %+v", timing)
+		}
+	}
+
+	for tick := 0; tick < profileWindow+5; tick++ {
+		world.Step()
+	}
+	timings := world.PhaseTimings()
+	if len(timings) != int(phaseCount) {
+		t.Fatalf("expected %d phases, got %d", phaseCount, len(timings))
+	}
+	var total time.Duration
+	for i, timing := range timings {
+		if timing.Name != stepPhaseNames[i] {
+			t.Fatalf("phase %d named %q, want %q", i, timing.Name, stepPhaseNames[i])
+		}
+		if timing.Average > timing.Total || timing.Last > timing.Total {
+			t.Fatalf("%s: rolling values exceed the total: %+v", timing.Name, timing)
+		}
+		total += timing.Total
+	}
+	if total <= 0 {
+		t.Fatal("expected step time to be recorded")
+	}
+
+	world.Reset(1)
+	for _, timing := range world.PhaseTimings() {
+		if timing.Total != 0 {
+			t.Fatalf("Reset should clear the profile, %s kept %v", timing.Name, timing.Total)
+		}
+	}
+}
+
+func TestPhaseTimingsRollingWindow(t *testing.T) {
+	var p stepProfile
+	for tick := 0; tick < profileWindow; tick++ {
+		p.startTick()
+		p.add(phaseLava, 3*time.Millisecond)
+	}
+	for tick := 0; tick < profileWindow; tick++ {
+		p.startTick()
+		p.add(phaseLava, time.Millisecond)
+	}
+	if got := p.sums[phaseLava] / profileWindow; got != time.Millisecond {
+		t.Fatalf("rolling average should only cover the last %d ticks, got %v", profileWindow, got)
+	}
+	if want := time.Duration(4*profileWindow) * time.Millisecond; p.totals[phaseLava] != want {
+		t.Fatalf("total = %v, want %v", p.totals[phaseLava], want)
+	}
+}
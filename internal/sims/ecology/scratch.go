@@ -0,0 +1,51 @@
+package ecology
+
+// stepScratch holds buffers that Step passes reuse from tick to tick instead
+// of allocating. Contents are only meaningful inside the pass that fills
+// them.
+type stepScratch struct {
+	// rainPass is the row pass of the separable rain morphology, and
+	// rainSpans bounds the part of each of its rows that can be non-zero.
+	// rainBounds does the same for the rain mask between morphology passes.
+	rainPass   []float32
+	rainSpans  []rowSpan
+	rainBounds []rowSpan
+
+	// visited and stack drive the flood fills that label vegetation
+	// clusters and rain islands; component collects an island's tiles.
+	visited   []bool
+	stack     []int
+	component []int
+
+	// vegCounts holds the packed Moore neighbour counts that drive
+	// succession; vegLanes and vegRows hold the packed tiles and row sums
+	// they are built from. trees lists the standing trees that may cast
+	// seeds this tick.
+	vegCounts []uint16
+	vegLanes  []uint16
+	vegRows   []uint16
+	trees     []int
+}
+
+// rowSpan is an inclusive column range [lo, hi] of one row, empty when
+// hi < lo.
+type rowSpan struct {
+	lo, hi int
+}
+
+// resize returns buf with length n, reallocating only when it is too small.
+// Reused elements keep their old values.
+func resize[T any](buf []T, n int) []T {
+	if cap(buf) < n {
+		return make([]T, n)
+	}
+	return buf[:n]
+}
+
+// clearedVisited returns the flood-fill visited buffer sized to the map and
+// reset to false.
+func (w *World) clearedVisited() []bool {
+	w.scratch.visited = resize(w.scratch.visited, w.w*w.h)
+	clear(w.scratch.visited)
+	return w.scratch.visited
+}
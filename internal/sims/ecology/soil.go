@@ -0,0 +1,67 @@
+package ecology
+
+// drySoilThreshold marks soil moisture below which a tile counts as dry in
+// the environment telemetry.
+const drySoilThreshold = 0.2
+
+// updateSoilMoisture advances the persistent soil-moisture layer: rain
+// infiltrates in proportion to the local rain mask, moisture evaporates at a
+// base rate, and heat from lava and fire dries the ground much faster. Lava
+// tiles themselves hold no water, open water keeps its bed saturated, and a
+// biome's water table keeps its soil from drying below the moisture floor.
+func (w *World) updateSoilMoisture() {
+	params := &w.cfg.Params
+	moisture, ground := w.soilMoisture, w.groundCurr[:len(w.soilMoisture)]
+	rain, heat := w.rainCurr, w.heatField
+	evaporation := params.SoilEvaporation * w.climate.Evaporation
+	for i, g := range ground {
+		switch g {
+		case GroundLava:
+			moisture[i] = 0
+			continue
+		case GroundWater:
+			moisture[i] = 1
+			continue
+		}
+		m := float64(moisture[i])
+		if i < len(rain) {
+			m += params.SoilRainGain * float64(rain[i]) * (1 - m)
+		}
+		m -= evaporation * m
+		if i < len(heat) {
+			m -= params.SoilHeatEvaporation * float64(heat[i])
+		}
+		if floor := groundTraits(g).moistureFloor; m < floor {
+			m = floor
+		}
+		moisture[i] = float32(clamp01(m))
+	}
+}
+
+// soilGrowthFactor scales vegetation growth chances by soil moisture and the
+// climate's growth multiplier. The soil term interpolates from SoilGrowthDry
+// on bone-dry ground to SoilGrowthWet on saturated ground, so the defaults
+// leave growth unchanged at the neutral moisture of 0.5.
+func (w *World) soilGrowthFactor(idx int) float64 {
+	if idx >= len(w.soilMoisture) {
+		return w.climate.Growth
+	}
+	m := float64(w.soilMoisture[idx])
+	return lerp(w.cfg.Params.SoilGrowthDry, w.cfg.Params.SoilGrowthWet, m) * w.climate.Growth
+}
+
+// soilFireFactor scales fire susceptibility by soil moisture, the biome's
+// fire multiplier, and the climate's fire multiplier: dry ground and the dry
+// season burn more readily and wet ground resists ignition, with no soil
+// change at 0.5.
+func (w *World) soilFireFactor(idx int) float64 {
+	if idx >= len(w.soilMoisture) {
+		return w.climate.Fire
+	}
+	m := float64(w.soilMoisture[idx])
+	factor := 1 + w.cfg.Params.FireSoilDampen*(1-2*m)
+	if factor < 0 {
+		return 0
+	}
+	return factor * groundTraits(w.groundCurr[idx]).fire * w.climate.Fire
+}
@@ -0,0 +1,106 @@
+package ecology
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSoilMoistureAccumulatesAndEvaporates(t *testing.T) {
+	world := newTestWorld(t, 4, 1, 8)
+	for i := range world.heatField {
+		world.heatField[i] = 0
+	}
+	world.rainCurr[0] = 1
+	world.heatField[2] = 1
+	world.groundCurr[3] = GroundLava
+
+	world.updateSoilMoisture()
+
+	initial := float32(world.cfg.Params.SoilMoistureInitial)
+	if world.soilMoisture[0] <= initial {
+		t.Fatalf("rain should raise soil moisture, got %.4f", world.soilMoisture[0])
+	}
+	if world.soilMoisture[1] >= initial {
+		t.Fatalf("dry tile should evaporate, got %.4f", world.soilMoisture[1])
+	}
+	if world.soilMoisture[2] >= world.soilMoisture[1] {
+		t.Fatalf("heat should dry faster than base evaporation: %.4f >= %.4f", world.soilMoisture[2], world.soilMoisture[1])
+	}
+	if world.soilMoisture[3] != 0 {
+		t.Fatalf("lava tiles should hold no moisture, got %.4f", world.soilMoisture[3])
+	}
+
+	for i := 0; i < 500; i++ {
+		world.updateSoilMoisture()
+	}
+	if world.soilMoisture[0] > 1 || world.soilMoisture[0] < 0.9 {
+		t.Fatalf("sustained rain should saturate soil within [0.9,1], got %.4f", world.soilMoisture[0])
+	}
+}
+
+func TestSoilMoistureScalesGrowthAndFire(t *testing.T) {
+	world := newTestWorld(t, 4, 1, 8)
+	world.soilMoisture[0] = 0
+	world.soilMoisture[1] = 0.5
+	world.soilMoisture[2] = 1
+
+	if got := world.soilGrowthFactor(1); math.Abs(got-1) > 1e-9 {
+		t.Fatalf("neutral moisture should leave growth unchanged, got %.3f", got)
+	}
+	if !(world.soilGrowthFactor(0) < 1 && world.soilGrowthFactor(2) > 1) {
+		t.Fatalf("expected dry < 1 < wet growth, got %.3f / %.3f", world.soilGrowthFactor(0), world.soilGrowthFactor(2))
+	}
+	if !(world.soilFireFactor(0) > 1 && world.soilFireFactor(2) < 1) {
+		t.Fatalf("expected dry ground to burn more readily, got dry %.3f wet %.3f", world.soilFireFactor(0), world.soilFireFactor(2))
+	}
+}
+
+func TestDrySoilSlowsVegetationSpread(t *testing.T) {
+	grow := func(moisture float32) int {
+		cfg := DefaultConfig()
+		cfg.Width = 24
+		cfg.Height = 24
+		cfg.Seed = 17
+		cfg.Params.RockChance = 0
+		cfg.Params.GrassPatchCount = 0
+		cfg.Params.GrassSpreadChance = 0.3
+		cfg.Params.RainSpawnChance = 0
+		cfg.Params.VolcanoProtoSpawnChance = 0
+		cfg.Params.SoilEvaporation = 0
+		cfg.Params.SoilMoistureInitial = float64(moisture)
+
+		world := NewWithConfig(cfg)
+		world.Reset(0)
+		world.vegCurr[12*cfg.Width+12] = VegetationGrass
+		copy(world.vegNext, world.vegCurr)
+		for i := 0; i < 12; i++ {
+			world.Step()
+		}
+		return world.Metrics().TotalVegetated
+	}
+
+	dry := grow(0.05)
+	wet := grow(0.95)
+	if dry >= wet {
+		t.Fatalf("expected wet soil to out-grow dry soil, dry=%d wet=%d", dry, wet)
+	}
+}
+
+func TestEnvironmentSummaryReportsSoilMoisture(t *testing.T) {
+	world := newTestWorld(t, 4, 1, 8)
+	world.soilMoisture[0] = 0
+	world.soilMoisture[1] = 0.1
+	world.soilMoisture[2] = 0.5
+	world.soilMoisture[3] = 1
+
+	env := world.EnvironmentSummary()
+	if math.Abs(env.SoilMoistureMean-0.4) > 1e-6 {
+		t.Fatalf("expected mean soil moisture 0.4, got %.6f", env.SoilMoistureMean)
+	}
+	if env.DrySoilTiles != 2 {
+		t.Fatalf("expected 2 dry tiles, got %d", env.DrySoilTiles)
+	}
+	if got := world.Field("soil_moisture"); len(got) != 4 || got[3] != 1 {
+		t.Fatalf("soil moisture field not exported: %v", got)
+	}
+}
@@ -0,0 +1,89 @@
+package ecology
+
+import "math/rand"
+
+// rngStreams gives every switchable subsystem its own random stream derived
+// from the world seed. Disabling one subsystem then leaves the draws of the
+// others untouched, so they replay the same way under the seed. Terrain
+// set-up at Reset keeps using the world's main generator.
+type rngStreams struct {
+	rain       *rand.Rand
+	volcano    *rand.Rand
+	lava       *rand.Rand
+	fire       *rand.Rand
+	vegetation *rand.Rand
+	agents     *rand.Rand
+}
+
+const (
+	streamRain = iota + 1
+	streamVolcano
+	streamLava
+	streamFire
+	streamVegetation
+	streamAgents
+)
+
+func newRNGStreams(seed int64) rngStreams {
+	return rngStreams{
+		rain:       rand.New(rand.NewSource(streamSeed(seed, streamRain))),
+		volcano:    rand.New(rand.NewSource(streamSeed(seed, streamVolcano))),
+		lava:       rand.New(rand.NewSource(streamSeed(seed, streamLava))),
+		fire:       rand.New(rand.NewSource(streamSeed(seed, streamFire))),
+		vegetation: rand.New(rand.NewSource(streamSeed(seed, streamVegetation))),
+		agents:     rand.New(rand.NewSource(streamSeed(seed, streamAgents))),
+	}
+}
+
+// reseed restarts every stream from seed.
+func (s *rngStreams) reseed(seed int64) {
+	s.rain.Seed(streamSeed(seed, streamRain))
+	s.volcano.Seed(streamSeed(seed, streamVolcano))
+	s.lava.Seed(streamSeed(seed, streamLava))
+	s.fire.Seed(streamSeed(seed, streamFire))
+	s.vegetation.Seed(streamSeed(seed, streamVegetation))
+	s.agents.Seed(streamSeed(seed, streamAgents))
+}
+
+// streamSeed derives the seed of stream n with a SplitMix64 finaliser, so
+// neighbouring world seeds and streams do not share sequences.
+func streamSeed(seed int64, n int) int64 {
+	z := uint64(seed) + uint64(n)*0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return int64(z ^ z>>31)
+}
+
+// subsystemSwitches records which subsystems with transient state ran on
+// the previous tick.
+type subsystemSwitches struct {
+	rain      bool
+	volcanoes bool
+	fire      bool
+}
+
+// quiesceDisabled clears the transient state of subsystems switched off
+// since the last tick, so stale rain, proto-volcanoes, or fires stop acting
+// on the rest of the world. Lava and agents already on the map stay where
+// they are; lava no longer flows or cools and agents no longer move or eat.
+func (w *World) quiesceDisabled() {
+	params := w.cfg.Params
+	if !params.Rain && w.running.rain {
+		w.rainRegions = w.rainRegions[:0]
+		clear(w.rainCurr)
+		clear(w.rainNext)
+	}
+	if !params.Volcanoes && w.running.volcanoes {
+		w.volcanoRegions = w.volcanoRegions[:0]
+		w.expiredVolcanoProtos = w.expiredVolcanoProtos[:0]
+		clear(w.volCurr)
+		clear(w.volNext)
+	}
+	if !params.Fire && w.running.fire {
+		clear(w.burnTTL)
+		clear(w.burnNext)
+		clear(w.lightningFlash)
+		w.lightningEvents = lightningEvents{}
+	}
+	w.running = subsystemSwitches{rain: params.Rain, volcanoes: params.Volcanoes, fire: params.Fire}
+}
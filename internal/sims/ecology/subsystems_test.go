@@ -0,0 +1,119 @@
+package ecology
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDisabledSubsystemsAreSkipped(t *testing.T) {
+	cfg := FromMap(map[string]string{
+		"rain": "false", "volcanoes": "false", "lava": "false", "fire": "false", "succession": "false",
+		"agents": "false", "soil": "false",
+	})
+	if p := cfg.Params; p.Rain || p.Volcanoes || p.Lava || p.Fire || p.Succession || p.Agents || p.Soil || !p.Wind {
+		t.Fatalf("switches not parsed: %+v", p)
+	}
+	cfg.Width = 8
+	cfg.Height = 8
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+
+	world.vegCurr[0] = VegetationGrass
+	world.burnTTL[0] = 3
+	world.setLavaCell(9, 4, 1, 0, true)
+	world.lavaElevation[10] = -50
+	world.rainRegions = append(world.rainRegions, rainRegion{})
+	world.rainCurr[20] = 1
+	world.addAgent(Agent{Kind: AgentHerbivore, X: 2, Y: 2, Energy: 1})
+	world.soilMoisture[30] = 0.9
+	vegetation := slices.Clone(world.vegCurr)
+
+	world.Step()
+
+	if world.burnTTL[0] != 0 {
+		t.Fatalf("disabled fire should be put out, ttl=%d", world.burnTTL[0])
+	}
+	if world.groundCurr[9] != GroundLava || world.lavaHeight[9] != 4 || world.groundCurr[10] == GroundLava {
+		t.Fatalf("disabled lava should stay put, ground=%v height=%d", world.groundCurr[9], world.lavaHeight[9])
+	}
+	if len(world.rainRegions) != 0 || world.rainCurr[20] != 0 {
+		t.Fatalf("disabled rain should clear its regions, got %d regions", len(world.rainRegions))
+	}
+	if !slices.Equal(world.vegCurr, vegetation) {
+		t.Fatal("disabled succession should leave vegetation unchanged")
+	}
+	if a := world.agents[0]; a.X != 2 || a.Y != 2 || a.Energy != 1 || a.Age != 0 {
+		t.Fatalf("disabled agents should stay put, got %+v", a)
+	}
+	if world.soilMoisture[30] != 0.9 {
+		t.Fatalf("disabled soil should hold its moisture, got %.3f", world.soilMoisture[30])
+	}
+
+	world.IgniteAt(1, 0)
+	world.SpawnVolcanoAt(4, 4)
+	if world.burnTTL[1] != 0 || len(world.volcanoRegions) != 0 {
+		t.Fatal("manual fire and volcanoes should be ignored while disabled")
+	}
+
+	if !world.SetBoolParameter("fire", true) || !world.cfg.Params.Fire {
+		t.Fatal("expected the HUD to switch fire back on")
+	}
+	if world.SetBoolParameter("lava_max_height", true) {
+		t.Fatal("non-bool parameters should be rejected")
+	}
+}
+
+func TestSubsystemSwitchKeepsOtherStreams(t *testing.T) {
+	run := func(fire bool) *World {
+		cfg := DefaultConfig()
+		cfg.Width = 48
+		cfg.Height = 48
+		cfg.Seed = 7
+		cfg.Params.Fire = fire
+		world := NewWithConfig(cfg)
+		world.Reset(0)
+		world.SpawnVolcanoAt(24, 24)
+		for tick := 0; tick < 60; tick++ {
+			world.Step()
+		}
+		return world
+	}
+	on, off := run(true), run(false)
+
+	if !slices.ContainsFunc(on.groundCurr, func(g Ground) bool { return g == GroundLava || g == GroundBasalt }) {
+		t.Fatal("expected lava to flow during the run")
+	}
+	if slices.Equal(on.vegCurr, off.vegCurr) {
+		t.Fatal("expected fire to change the vegetation")
+	}
+	if !slices.Equal(on.groundCurr, off.groundCurr) || !slices.Equal(on.lavaHeight, off.lavaHeight) {
+		t.Fatal("switching fire off changed the volcano and lava history")
+	}
+	if !slices.Equal(on.rainCurr, off.rainCurr) {
+		t.Fatal("switching fire off changed the rain history")
+	}
+}
+
+func TestAgentsDrawFromTheirOwnStream(t *testing.T) {
+	run := func(drawMain bool) []Agent {
+		world := newTestWorld(t, 32, 32, 11, func(cfg *Config) {
+			cfg.Params.GrassPatchCount = 12
+			cfg.Params.HerbivoreDensity = 0.2
+			cfg.Params.PredatorDensity = 0.05
+		})
+		for tick := 0; tick < 40; tick++ {
+			if drawMain {
+				world.rng.Int63()
+			}
+			world.applyAgents()
+		}
+		return slices.Clone(world.agents)
+	}
+	agents := run(false)
+	if len(agents) == 0 {
+		t.Fatal("expected agents to survive the run")
+	}
+	if !slices.Equal(agents, run(true)) {
+		t.Fatal("draws from the world's main generator changed agent behaviour")
+	}
+}
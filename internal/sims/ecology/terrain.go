@@ -0,0 +1,252 @@
+package ecology
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Terrain generator modes selected by the `terrain` config key.
+const (
+	// TerrainFlat keeps the classic flat dirt world with sprinkled rock.
+	TerrainFlat = "flat"
+	// TerrainNoise builds rolling fBm hills crossed by ridged mountain ranges.
+	TerrainNoise = "noise"
+	// TerrainPlates raises mountains along converging Voronoi plate boundaries.
+	TerrainPlates = "plates"
+)
+
+// terrainMaxRelief caps TerrainRelief so elevations and cones stay in int16.
+const terrainMaxRelief = 4096
+
+func validTerrain(mode string) bool {
+	switch mode {
+	case TerrainFlat, TerrainNoise, TerrainPlates:
+		return true
+	default:
+		return false
+	}
+}
+
+// generateTerrain shapes the initial elevation, tectonic map, and biomes for
+// the configured terrain mode. Flat terrain leaves the world untouched so
+// existing seeds reproduce exactly.
+func (w *World) generateTerrain(seed int64) {
+	total := w.w * w.h
+	if total == 0 || len(w.terrainElevation) != total {
+		return
+	}
+
+	var height, tectonic []float64
+	switch w.cfg.Terrain {
+	case TerrainNoise:
+		height, tectonic = w.noiseHeightmap(seed)
+	case TerrainPlates:
+		height, tectonic = w.plateHeightmap(seed)
+	default:
+		return
+	}
+	normalizeField(height)
+
+	relief := float64(w.cfg.Params.TerrainRelief)
+	for i := 0; i < total; i++ {
+		elev := int16(math.Round(height[i] * relief))
+		w.terrainElevation[i] = elev
+		w.lavaElevation[i] = elev
+		if i < len(w.tectonic) {
+			w.tectonic[i] = float32(clamp01(tectonic[i]))
+		}
+	}
+
+	w.seedTerrainBiomes(height, seed)
+}
+
+// noiseHeightmap blends broad fBm hills with ridged noise so mountain ranges
+// run as sharp crests across the map. The ridge strength doubles as the
+// tectonic map, steering proto-volcanoes toward the ranges. Both noises are
+// periodic on a torus.
+func (w *World) noiseHeightmap(seed int64) ([]float64, []float64) {
+	total := w.w * w.h
+	height := make([]float64, total)
+	tectonic := make([]float64, total)
+	scale := w.cfg.Params.TerrainScale
+	for y := 0; y < w.h; y++ {
+		for x := 0; x < w.w; x++ {
+			idx := y*w.w + x
+			base := w.periodicNoise(float64(x), float64(y), func(x, y float64) float64 {
+				return fbmNoise2D(x*scale, y*scale, 5, 0.5, 2, seed)
+			})
+			ridge := w.periodicNoise(float64(x), float64(y), func(x, y float64) float64 {
+				return fbmNoise2D(x*scale*1.7+31.7, y*scale*1.7-11.3, 4, 0.5, 2, seed+101)
+			})
+			ridge = 1 - math.Abs(2*ridge-1)
+			ridge = ridge * ridge * ridge
+			height[idx] = 0.65*base + 0.35*ridge
+			tectonic[idx] = ridge
+		}
+	}
+	normalizeField(tectonic)
+	return height, tectonic
+}
+
+type terrainPlate struct {
+	x, y    float64
+	vx, vy  float64
+	oceanic bool
+}
+
+// plateHeightmap partitions the map into Voronoi plates with random drift.
+// Oceanic plates sit low and continental plates high; boundaries where two
+// plates converge are pushed up into mountain belts while diverging edges
+// sink into rifts. Proximity to any boundary becomes the tectonic map. On a
+// torus plates are measured across the edges and the detail noise is
+// periodic, so plates and their boundaries continue over the seams.
+func (w *World) plateHeightmap(seed int64) ([]float64, []float64) {
+	total := w.w * w.h
+	height := make([]float64, total)
+	tectonic := make([]float64, total)
+
+	rng := rand.New(rand.NewSource(seed))
+	count := max(w.cfg.Params.TerrainPlateCount, 2)
+	plates := make([]terrainPlate, count)
+	for i := range plates {
+		angle := rng.Float64() * 2 * math.Pi
+		plates[i] = terrainPlate{
+			x:       rng.Float64() * float64(w.w),
+			y:       rng.Float64() * float64(w.h),
+			vx:      math.Cos(angle),
+			vy:      math.Sin(angle),
+			oceanic: rng.Float64() < 0.4,
+		}
+	}
+
+	width := math.Max(4, float64(min(w.w, w.h))/24)
+	scale := w.cfg.Params.TerrainScale
+	for y := 0; y < w.h; y++ {
+		for x := 0; x < w.w; x++ {
+			idx := y*w.w + x
+			px := float64(x) + 0.5
+			py := float64(y) + 0.5
+
+			first, second := -1, -1
+			d1, d2 := math.Inf(1), math.Inf(1)
+			for i, plate := range plates {
+				d := math.Hypot(w.wrapDelta(px-plate.x, w.w), w.wrapDelta(py-plate.y, w.h))
+				if d < d1 {
+					second, d2 = first, d1
+					first, d1 = i, d
+				} else if d < d2 {
+					second, d2 = i, d
+				}
+			}
+
+			a := plates[first]
+			base := 0.55
+			if a.oceanic {
+				base = 0.2
+			}
+			detail := w.periodicNoise(float64(x), float64(y), func(x, y float64) float64 {
+				return fbmNoise2D(x*scale, y*scale, 4, 0.5, 2, seed+7)
+			}) - 0.5
+
+			boundary := 0.0
+			push := 0.0
+			if second >= 0 {
+				b := plates[second]
+				boundary = math.Exp(-((d2 - d1) / 2) / width)
+				nx, ny := w.wrapDelta(b.x-a.x, w.w), w.wrapDelta(b.y-a.y, w.h)
+				if length := math.Hypot(nx, ny); length > 0 {
+					nx /= length
+					ny /= length
+				}
+				converge := (a.vx-b.vx)*nx + (a.vy-b.vy)*ny
+				if converge > 0 {
+					push = 0.25 * converge * boundary
+				} else {
+					push = 0.1 * converge * boundary
+				}
+			}
+
+			height[idx] = base + 0.5*detail + push
+			tectonic[idx] = boundary
+		}
+	}
+	return height, tectonic
+}
+
+// seedTerrainBiomes floods basins below TerrainWaterLevel into lakes, caps
+// peaks above TerrainMountainLevel with mountain and their shoulders with
+// rock, and seeds the remaining lowlands with vegetation and soil moisture
+// from a wetness field that favours low ground near water.
+func (w *World) seedTerrainBiomes(height []float64, seed int64) {
+	params := w.cfg.Params
+	waterLevel := params.TerrainWaterLevel
+	mountain := params.TerrainMountainLevel
+	rockLevel := mountain - 0.08
+	relief := float64(params.TerrainRelief)
+	scale := params.TerrainScale * 1.5
+
+	wetness := make([]float64, len(height))
+	for y := 0; y < w.h; y++ {
+		for x := 0; x < w.w; x++ {
+			idx := y*w.w + x
+			wetness[idx] = w.periodicNoise(float64(x), float64(y), func(x, y float64) float64 {
+				return fbmNoise2D(x*scale+57.1, y*scale+13.9, 3, 0.5, 2, seed+211)
+			})
+		}
+	}
+	normalizeField(wetness)
+
+	for i, h := range height {
+		switch {
+		case h < waterLevel:
+			w.groundCurr[i] = GroundWater
+			w.waterDepth[i] = float32((waterLevel-h)*relief + params.WaterLakeDepth)
+			w.soilMoisture[i] = 1
+			continue
+		case h >= mountain:
+			w.groundCurr[i] = GroundMountain
+			continue
+		case h >= rockLevel:
+			w.groundCurr[i] = GroundRock
+			continue
+		}
+
+		lowland := 1.0
+		if span := rockLevel - waterLevel; span > 0 {
+			lowland = 1 - (h-waterLevel)/span
+		}
+		wet := clamp01(0.6*wetness[i] + 0.4*lowland)
+		w.soilMoisture[i] = float32(clamp01(params.SoilMoistureInitial + wet - 0.5))
+		if wet < 0.45 || w.rng.Float64() >= wet {
+			continue
+		}
+		switch {
+		case wet > 0.72:
+			w.vegCurr[i] = VegetationTree
+		case wet > 0.6:
+			w.vegCurr[i] = VegetationShrub
+		default:
+			w.vegCurr[i] = VegetationGrass
+		}
+	}
+}
+
+// normalizeField rescales values in place to span [0,1].
+func normalizeField(values []float64) {
+	if len(values) == 0 {
+		return
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	span := hi - lo
+	for i, v := range values {
+		if span <= 0 {
+			values[i] = 0
+			continue
+		}
+		values[i] = (v - lo) / span
+	}
+}
@@ -0,0 +1,143 @@
+package ecology
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTerrainWorld(t *testing.T, terrain string, seed int64) *World {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.Width = 96
+	cfg.Height = 96
+	cfg.Seed = seed
+	cfg.Terrain = terrain
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+	return world
+}
+
+func groundCounts(world *World) map[Ground]int {
+	counts := map[Ground]int{}
+	for _, g := range world.groundCurr {
+		counts[g]++
+	}
+	return counts
+}
+
+func TestFlatTerrainKeepsClassicWorld(t *testing.T) {
+	world := newTerrainWorld(t, TerrainFlat, 4)
+	for i, elev := range world.lavaElevation {
+		if elev != 0 || world.terrainElevation[i] != 0 {
+			t.Fatalf("flat terrain should start with zero elevation, got %d at %d", elev, i)
+		}
+	}
+	if !reflect.DeepEqual(world.tectonic, loadTectonicMap(world.w, world.h)) {
+		t.Fatalf("flat terrain should keep the baseline tectonic map")
+	}
+	counts := groundCounts(world)
+	if counts[GroundWater] != 0 || counts[GroundMountain] != 0 {
+		t.Fatalf("flat terrain should only hold dirt and rock, got %v", counts)
+	}
+}
+
+func TestNoiseTerrainBuildsReliefAndBiomes(t *testing.T) {
+	world := newTerrainWorld(t, TerrainNoise, 4)
+	relief := int16(world.cfg.Params.TerrainRelief)
+
+	lo, hi := world.lavaElevation[0], world.lavaElevation[0]
+	for _, elev := range world.lavaElevation {
+		lo = min(lo, elev)
+		hi = max(hi, elev)
+	}
+	if lo != 0 || hi != relief {
+		t.Fatalf("expected elevation to span 0..%d, got %d..%d", relief, lo, hi)
+	}
+
+	counts := groundCounts(world)
+	if counts[GroundWater] == 0 || counts[GroundMountain] == 0 || counts[GroundDirt] == 0 {
+		t.Fatalf("expected lakes, mountains, and lowland, got %v", counts)
+	}
+	for i, g := range world.groundCurr {
+		if g == GroundWater && world.waterDepth[i] < float32(world.cfg.Params.WaterLakeDepth) {
+			t.Fatalf("seeded lake at %d is shallower than the lake depth", i)
+		}
+		if g != GroundDirt && g != GroundRock && world.vegCurr[i] != VegetationNone {
+			t.Fatalf("vegetation seeded on %v at %d", g, i)
+		}
+	}
+
+	again := newTerrainWorld(t, TerrainNoise, 4)
+	if !reflect.DeepEqual(world.lavaElevation, again.lavaElevation) || !reflect.DeepEqual(world.groundCurr, again.groundCurr) {
+		t.Fatalf("terrain should be deterministic for a seed")
+	}
+	other := newTerrainWorld(t, TerrainNoise, 5)
+	if reflect.DeepEqual(world.lavaElevation, other.lavaElevation) {
+		t.Fatalf("different seeds should produce different terrain")
+	}
+}
+
+func TestPlateTerrainRaisesTectonicBoundaries(t *testing.T) {
+	world := newTerrainWorld(t, TerrainPlates, 8)
+
+	high, low := 0, 0
+	for _, v := range world.tectonic {
+		switch {
+		case v > 0.6:
+			high++
+		case v < 0.2:
+			low++
+		}
+	}
+	if high == 0 || low == 0 {
+		t.Fatalf("expected narrow tectonic belts between plate interiors, got %d high / %d low", high, low)
+	}
+	if high > len(world.tectonic)/2 {
+		t.Fatalf("plate boundaries should cover a minority of the map, got %d of %d", high, len(world.tectonic))
+	}
+
+	var boundary, interior float64
+	var nb, ni int
+	for i, v := range world.tectonic {
+		if v > 0.6 {
+			boundary += float64(world.lavaElevation[i])
+			nb++
+		} else if v < 0.2 {
+			interior += float64(world.lavaElevation[i])
+			ni++
+		}
+	}
+	if boundary/float64(nb) <= interior/float64(ni) {
+		t.Fatalf("converging boundaries should stand above plate interiors on average")
+	}
+}
+
+func TestEruptionConesBuildOnTerrain(t *testing.T) {
+	world := newTerrainWorld(t, TerrainNoise, 4)
+	before := append([]int16(nil), world.lavaElevation...)
+	cx, cy := 48, 48
+	world.buildLavaElevation(volcanoProtoRegion{cx: float64(cx) + 0.5, cy: float64(cy) + 0.5, radius: 6})
+
+	centre := cy*world.w + cx
+	if world.lavaElevation[centre] < world.terrainElevation[centre]+2 {
+		t.Fatalf("cone should rise above the terrain, got %d over ground %d", world.lavaElevation[centre], world.terrainElevation[centre])
+	}
+	for i, elev := range world.lavaElevation {
+		if elev < before[i] {
+			t.Fatalf("building a cone must never lower terrain at %d", i)
+		}
+	}
+}
+
+func TestFromMapParsesTerrain(t *testing.T) {
+	cfg := FromMap(map[string]string{"terrain": "plates", "terrain_relief": "40", "terrain_plate_count": "1"})
+	if cfg.Terrain != TerrainPlates || cfg.Params.TerrainRelief != 40 {
+		t.Fatalf("expected plates terrain with relief 40, got %q / %d", cfg.Terrain, cfg.Params.TerrainRelief)
+	}
+	if cfg.Params.TerrainPlateCount != DefaultConfig().Params.TerrainPlateCount {
+		t.Fatalf("a single plate should be rejected, got %d", cfg.Params.TerrainPlateCount)
+	}
+	if cfg := FromMap(map[string]string{"terrain": "volcanic"}); cfg.Terrain != TerrainFlat {
+		t.Fatalf("unknown terrain should fall back to flat, got %q", cfg.Terrain)
+	}
+}
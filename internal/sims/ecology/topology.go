@@ -0,0 +1,116 @@
+package ecology
+
+import "math"
+
+// World topologies selected by the `topology` config key.
+const (
+	// TopologyBounded clips every neighbourhood at the map border.
+	TopologyBounded = "bounded"
+	// TopologyTorus wraps both axes, so tiles on opposite edges are
+	// neighbours and regions drifting off one edge re-enter on the other.
+	TopologyTorus = "torus"
+)
+
+func validTopology(mode string) bool {
+	switch mode {
+	case TopologyBounded, TopologyTorus:
+		return true
+	default:
+		return false
+	}
+}
+
+// wrapAxis maps coordinate v onto an axis of n tiles. A torus wraps it; a
+// bounded world reports false for coordinates off the map.
+func (w *World) wrapAxis(v, n int) (int, bool) {
+	if v >= 0 && v < n {
+		return v, true
+	}
+	if !w.torus || n <= 0 {
+		return v, false
+	}
+	v %= n
+	if v < 0 {
+		v += n
+	}
+	return v, true
+}
+
+// axisNeighbor maps v onto an axis of n cells for a finite difference:
+// wrapped when wrap is set, otherwise clamped to the edge cell.
+func axisNeighbor(v, n int, wrap bool) int {
+	if wrap {
+		return ((v % n) + n) % n
+	}
+	return min(max(v, 0), n-1)
+}
+
+// tileIndex returns the index of tile (x, y), wrapping on a torus. It
+// reports false for off-map tiles of a bounded world.
+func (w *World) tileIndex(x, y int) (int, bool) {
+	x, okX := w.wrapAxis(x, w.w)
+	y, okY := w.wrapAxis(y, w.h)
+	if !okX || !okY {
+		return -1, false
+	}
+	return y*w.w + x, true
+}
+
+// tileSpan limits the tile range [lo, hi] of a footprint on an axis of n
+// tiles. Bounded worlds clip it to the map. A torus keeps it unclipped for
+// tileIndex to wrap, but at most n tiles wide so no tile is visited twice.
+func (w *World) tileSpan(lo, hi, n int) (int, int) {
+	if !w.torus {
+		return max(lo, 0), min(hi, n-1)
+	}
+	if hi-lo >= n {
+		hi = lo + n - 1
+	}
+	return lo, hi
+}
+
+// wrapDelta shortens the displacement d along an axis of size tiles to the
+// nearest image on a torus. Bounded worlds return d unchanged.
+func (w *World) wrapDelta(d float64, size int) float64 {
+	if !w.torus || size <= 0 {
+		return d
+	}
+	s := float64(size)
+	d = math.Mod(d, s)
+	if d > s/2 {
+		d -= s
+	} else if d < -s/2 {
+		d += s
+	}
+	return d
+}
+
+// periodicNoise samples noise at (x, y) in tile units. On a torus it blends
+// the samples one map width and height away, weighted by position, so the
+// field runs on without a seam where the edges meet. Bounded worlds sample
+// noise directly.
+func (w *World) periodicNoise(x, y float64, noise func(x, y float64) float64) float64 {
+	if !w.torus || w.w <= 0 || w.h <= 0 {
+		return noise(x, y)
+	}
+	width, height := float64(w.w), float64(w.h)
+	x = w.wrapPosition(x, w.w)
+	y = w.wrapPosition(y, w.h)
+	u := smoothstep(0, 1, x/width)
+	v := smoothstep(0, 1, y/height)
+	return noise(x, y)*(1-u)*(1-v) + noise(x-width, y)*u*(1-v) +
+		noise(x, y-height)*(1-u)*v + noise(x-width, y-height)*u*v
+}
+
+// wrapPosition folds a continuous coordinate into [0, size) on a torus.
+func (w *World) wrapPosition(v float64, size int) float64 {
+	if !w.torus || size <= 0 {
+		return v
+	}
+	s := float64(size)
+	v = math.Mod(v, s)
+	if v < 0 {
+		v += s
+	}
+	return v
+}
@@ -0,0 +1,197 @@
+package ecology
+
+import (
+	"math"
+	"testing"
+)
+
+// onTopology lays the world out on topology with vegetation growth off.
+func onTopology(topology string) func(*Config) {
+	return func(cfg *Config) {
+		cfg.Topology = topology
+		cfg.Params.GrassSpreadChance = 0
+		cfg.Params.ShrubGrowthChance = 0
+		cfg.Params.TreeGrowthChance = 0
+	}
+}
+
+func TestTopologyFireSpreadsAcrossEdge(t *testing.T) {
+	for _, topology := range []string{TopologyBounded, TopologyTorus} {
+		world := newTestWorld(t, 5, 3, 2, onTopology(topology))
+		world.cfg.Params.FireSpreadChance = 1
+		world.cfg.Params.BurnTTL = 2
+		world.cfg.Params.FireSoilDampen = 0
+		world.vegCurr[1*world.w+0] = VegetationGrass
+		world.vegCurr[1*world.w+4] = VegetationGrass
+		copy(world.vegNext, world.vegCurr)
+
+		world.IgniteAt(0, 1)
+		world.Step()
+
+		burning := world.burnTTL[1*world.w+4] > 0
+		if burning != (topology == TopologyTorus) {
+			t.Fatalf("%s: fire crossing the west edge = %v", topology, burning)
+		}
+	}
+}
+
+func TestTopologyLavaRoutesAcrossSeam(t *testing.T) {
+	for _, topology := range []string{TopologyBounded, TopologyTorus} {
+		world := newTestWorld(t, 6, 1, 2, onTopology(topology))
+		for x := 0; x < world.w; x++ {
+			world.lavaElevation[x] = 10
+		}
+		world.lavaElevation[0] = 12
+		world.lavaElevation[5] = 0
+
+		out, _, downhill := world.pickDownhill(0)
+		if !downhill {
+			t.Fatalf("%s: expected a downhill neighbour", topology)
+		}
+		want := 1
+		if topology == TopologyTorus {
+			want = 5
+		}
+		if out != want {
+			t.Fatalf("%s: lava routed to %d, want %d", topology, out, want)
+		}
+	}
+}
+
+func TestTopologyRainRasterizesAcrossEdge(t *testing.T) {
+	for _, topology := range []string{TopologyBounded, TopologyTorus} {
+		world := newTestWorld(t, 11, 11, 2, onTopology(topology))
+		for i := range world.rainNext {
+			world.rainNext[i] = 0
+		}
+		world.rasterizeRainRegion(&rainRegion{
+			cx:            0.5,
+			cy:            5.5,
+			radiusX:       3.2,
+			radiusY:       3.2,
+			strength:      1,
+			falloff:       1.15,
+			noiseScale:    0.01,
+			noiseStretchX: 1,
+			noiseStretchY: 1,
+		})
+
+		near, wrapped := world.rainNext[5*world.w+1], world.rainNext[5*world.w+10]
+		if near <= 0 {
+			t.Fatalf("%s: expected rain beside the centre", topology)
+		}
+		if topology == TopologyBounded && wrapped != 0 {
+			t.Fatalf("bounded rain leaked across the edge: %.3f", wrapped)
+		}
+		if topology == TopologyTorus && math.Abs(float64(wrapped-near)) > 1e-6 {
+			t.Fatalf("torus rain should be symmetric across the seam, got %.3f and %.3f", near, wrapped)
+		}
+	}
+}
+
+func TestTopologyClustersAndNeighboursWrap(t *testing.T) {
+	for _, topology := range []string{TopologyBounded, TopologyTorus} {
+		world := newTestWorld(t, 6, 6, 2, onTopology(topology))
+		for _, idx := range []int{0, 5, 30, 35} {
+			world.vegCurr[idx] = VegetationGrass
+		}
+
+		world.updateMetrics(world.vegCurr)
+		grass, _, _ := unpackLanes(world.mooreNeighborCounts()[0])
+
+		clusters, corner := 4, uint8(0)
+		if topology == TopologyTorus {
+			clusters, corner = 1, 3
+		}
+		total := 0
+		for _, count := range world.Metrics().ClusterHistogram {
+			total += count
+		}
+		if total != clusters {
+			t.Fatalf("%s: expected %d corner clusters, got %d (%v)", topology, clusters, total, world.Metrics().ClusterHistogram)
+		}
+		if grass != corner {
+			t.Fatalf("%s: expected %d grass neighbours at the corner, got %d", topology, corner, grass)
+		}
+	}
+}
+
+func TestTorusWindIsSeamless(t *testing.T) {
+	world := newTestWorld(t, 64, 48, 2, onTopology(TopologyTorus))
+	for _, y := range []float64{3.5, 20, 40.25} {
+		ax, ay := world.windVector(0.001, y)
+		bx, by := world.windVector(63.999, y)
+		if math.Hypot(ax-bx, ay-by) > 0.05 {
+			t.Fatalf("wind jumps across the seam at y=%v: (%.3f,%.3f) vs (%.3f,%.3f)", y, ax, ay, bx, by)
+		}
+	}
+}
+
+// seamSteps returns the mean difference between the tiles facing each other
+// across the wrap seams and the mean difference between neighbours just
+// inside the edges, where the periodic blend is weighted the same way.
+func seamSteps(world *World, diff func(a, b int) float64) (seam, inside float64) {
+	var seamCount, insideCount int
+	step := func(a, b, pos, size int) {
+		switch {
+		case pos == size-1:
+			seam += diff(a, b)
+			seamCount++
+		case pos < 2 || pos >= size-3:
+			inside += diff(a, b)
+			insideCount++
+		}
+	}
+	for y := 0; y < world.h; y++ {
+		for x := 0; x < world.w; x++ {
+			idx := y*world.w + x
+			step(idx, y*world.w+(x+1)%world.w, x, world.w)
+			step(idx, ((y+1)%world.h)*world.w+x, y, world.h)
+		}
+	}
+	return seam / float64(seamCount), inside / float64(insideCount)
+}
+
+func TestTorusTerrainIsSeamless(t *testing.T) {
+	for _, terrain := range []string{TerrainNoise, TerrainPlates} {
+		world := newTestWorld(t, 64, 48, 2, onTopology(TopologyTorus), func(cfg *Config) {
+			cfg.Terrain = terrain
+			cfg.Params.Biomes = true
+		})
+		layers := map[string]func(a, b int) float64{
+			"elevation": func(a, b int) float64 {
+				return math.Abs(float64(world.lavaElevation[a] - world.lavaElevation[b]))
+			},
+			"tectonic": func(a, b int) float64 {
+				return math.Abs(float64(world.tectonic[a] - world.tectonic[b]))
+			},
+			"soil moisture": func(a, b int) float64 {
+				return math.Abs(float64(world.soilMoisture[a] - world.soilMoisture[b]))
+			},
+			"climate zone": func(a, b int) float64 {
+				if world.climateZone[a] != world.climateZone[b] {
+					return 1
+				}
+				return 0
+			},
+		}
+		for name, diff := range layers {
+			seam, inside := seamSteps(world, diff)
+			if seam > 2*inside {
+				t.Errorf("%s %s: seam steps average %.3f against %.3f inside", terrain, name, seam, inside)
+			}
+		}
+	}
+}
+
+func TestTopologyConfigKey(t *testing.T) {
+	if cfg := FromMap(map[string]string{"topology": "torus"}); cfg.Topology != TopologyTorus {
+		t.Fatalf("expected torus topology, got %q", cfg.Topology)
+	}
+	if cfg := FromMap(map[string]string{"topology": "klein"}); cfg.Topology != TopologyBounded {
+		t.Fatalf("unknown topology should fall back to bounded, got %q", cfg.Topology)
+	}
+	if _, err := ParseConfig(map[string]string{"topology": "klein"}); err == nil {
+		t.Fatalf("expected ParseConfig to reject an unknown topology")
+	}
+}
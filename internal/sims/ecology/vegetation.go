@@ -0,0 +1,229 @@
+package ecology
+
+import "math"
+
+// vegetationEvents tallies the per-tick vegetation turnover that is merged
+// into VegetationMetrics after the census.
+type vegetationEvents struct {
+	droughtDeaths  int
+	crowdingDeaths int
+	ageDeaths      int
+	seedlings      int
+	lavaRegrowth   int
+}
+
+// applyVegetation advances succession, plant health, and mortality for one
+// tick, then disperses tree seeds and weathers cooled lava. Results land in
+// vegNext; the caller swaps buffers.
+func (w *World) applyVegetation() {
+	total := w.w * w.h
+	w.vegEvents = vegetationEvents{}
+	if total == 0 {
+		return
+	}
+
+	neighbors := w.mooreNeighborCounts()[:total]
+
+	params := &w.cfg.Params
+	thresholdGrass := uint8(params.GrassNeighborThreshold)
+	thresholdShrub := uint8(params.ShrubNeighborThreshold)
+	thresholdTree := uint8(params.TreeNeighborThreshold)
+
+	vegCurr, vegNext := w.vegCurr[:total], w.vegNext[:total]
+	vegAge, vegHealth := w.vegAge[:total], w.vegHealth[:total]
+	burnTTL := w.burnTTL[:total]
+	trees := w.scratch.trees[:0]
+
+	for i, current := range vegCurr {
+		next := current
+
+		if current == VegetationNone {
+			vegAge[i] = 0
+			vegHealth[i] = 1
+		}
+
+		if burnTTL[i] > 0 {
+			vegNext[i] = next
+			continue
+		}
+
+		grassNeighbors, shrubNeighbors, treeNeighbors := unpackLanes(neighbors[i])
+		if current != VegetationNone {
+			if current == VegetationTree {
+				// Seeds are cast by the trees standing at the start of the
+				// tick, including those that die below.
+				trees = append(trees, i)
+			}
+			if vegAge[i] < math.MaxInt16 {
+				vegAge[i]++
+			}
+			if w.vegetationDies(i, current, treeNeighbors) {
+				vegNext[i] = VegetationNone
+				vegAge[i] = 0
+				vegHealth[i] = 1
+				continue
+			}
+		}
+
+		switch current {
+		case VegetationNone:
+			if grassNeighbors >= thresholdGrass {
+				chance := params.GrassSpreadChance * w.soilGrowthFactor(i) * w.biomeGrowth(w.groundCurr[i], VegetationGrass)
+				if chance > 0 && w.streams.vegetation.Float64() < chance {
+					next = VegetationGrass
+				}
+			}
+		case VegetationGrass:
+			if grassNeighbors >= thresholdShrub {
+				if w.streams.vegetation.Float64() < params.ShrubGrowthChance*w.soilGrowthFactor(i)*w.biomeGrowth(w.groundCurr[i], VegetationShrub) {
+					next = VegetationShrub
+				}
+			}
+		case VegetationShrub:
+			if shrubNeighbors >= thresholdTree {
+				if w.streams.vegetation.Float64() < params.TreeGrowthChance*w.soilGrowthFactor(i)*w.biomeGrowth(w.groundCurr[i], VegetationTree) {
+					next = VegetationTree
+				}
+			}
+		}
+
+		vegNext[i] = next
+	}
+	w.scratch.trees = trees
+
+	w.disperseTreeSeeds()
+	w.weatherLavaScars()
+}
+
+// vegetationDies applies drought and crowding stress to the plant at idx and
+// rolls for old-age mortality. It reports whether the plant died this tick and
+// records the cause in the turnover counters.
+func (w *World) vegetationDies(idx int, veg Vegetation, treeNeighbors uint8) bool {
+	params := &w.cfg.Params
+
+	drought := 0.0
+	if threshold := params.VegDroughtThreshold; threshold > 0 && idx < len(w.soilMoisture) {
+		if m := float64(w.soilMoisture[idx]); m < threshold {
+			drought = params.VegDroughtDamage * (threshold - m) / threshold
+		}
+	}
+
+	crowding := 0.0
+	if veg == VegetationTree && params.TreeCrowdingThreshold > 0 && int(treeNeighbors) >= params.TreeCrowdingThreshold {
+		crowding = params.TreeCrowdingDamage
+	}
+
+	health := float64(w.vegHealth[idx])
+	if drought > 0 || crowding > 0 {
+		health -= drought + crowding
+	} else {
+		health += params.VegHealthRecovery
+	}
+	health = clamp01(health)
+	w.vegHealth[idx] = float32(health)
+
+	if health <= 0 {
+		if drought >= crowding {
+			w.vegEvents.droughtDeaths++
+		} else {
+			w.vegEvents.crowdingDeaths++
+		}
+		return true
+	}
+
+	if veg == VegetationTree && params.TreeAgeMortalityChance > 0 && int(w.vegAge[idx]) > params.TreeAgeMortalityStart {
+		if w.streams.vegetation.Float64() < params.TreeAgeMortalityChance {
+			w.vegEvents.ageDeaths++
+			return true
+		}
+	}
+	return false
+}
+
+// disperseTreeSeeds lets each standing tree listed by applyVegetation cast a
+// seed at range. Seeds fly in a random direction, pushed downwind by
+// TreeSeedWind, and establish a sapling (shrub) on bare or grassy soil subject
+// to soil moisture and the biome's shrub growth.
+func (w *World) disperseTreeSeeds() {
+	params := &w.cfg.Params
+	if params.TreeSeedChance <= 0 || params.TreeSeedDistance < 1 {
+		return
+	}
+
+	for _, idx := range w.scratch.trees {
+		x, y := idx%w.w, idx/w.w
+		if w.streams.vegetation.Float64() >= params.TreeSeedChance {
+			continue
+		}
+
+		angle := w.streams.vegetation.Float64() * 2 * math.Pi
+		dist := 1 + w.streams.vegetation.Float64()*float64(params.TreeSeedDistance-1)
+		offsetX := math.Cos(angle) * dist
+		offsetY := math.Sin(angle) * dist
+		if params.TreeSeedWind > 0 {
+			windX, windY := w.windVector(float64(x)+0.5, float64(y)+0.5)
+			offsetX += windX * params.TreeSeedWind * dist
+			offsetY += windY * params.TreeSeedWind * dist
+		}
+		tx := int(math.Floor(float64(x) + 0.5 + offsetX))
+		ty := int(math.Floor(float64(y) + 0.5 + offsetY))
+		target, ok := w.tileIndex(tx, ty)
+		if !ok || target == idx || !soilGround(w.groundCurr[target]) {
+			continue
+		}
+		if w.burnTTL[target] > 0 || w.burnNext[target] > 0 {
+			continue
+		}
+		if veg := w.vegNext[target]; veg != VegetationNone && veg != VegetationGrass {
+			continue
+		}
+		growth := w.soilGrowthFactor(target) * w.biomeGrowth(w.groundCurr[target], VegetationShrub)
+		if growth < 1 && w.streams.vegetation.Float64() >= growth {
+			continue
+		}
+
+		w.vegNext[target] = VegetationShrub
+		w.vegAge[target] = 0
+		w.vegHealth[target] = 1
+		w.vegEvents.seedlings++
+	}
+}
+
+// weatherLavaScars ages basalt left behind by cooled lava. Once a scar is
+// older than LavaRegrowthDelay it weathers back to the tile's land ground
+// with LavaRegrowthChance per tick and is immediately colonised by pioneer
+// grass where that ground carries any.
+// The elevation the flow built stays.
+func (w *World) weatherLavaScars() {
+	params := w.cfg.Params
+	for i := range w.lavaScar {
+		if w.groundCurr[i] != GroundBasalt {
+			w.lavaScar[i] = 0
+			continue
+		}
+		if w.lavaScar[i] == 0 {
+			// Basalt painted from a map starts ageing when first seen.
+			w.lavaScar[i] = 1
+		}
+		if int(w.lavaScar[i]) <= params.LavaRegrowthDelay {
+			if w.lavaScar[i] < math.MaxUint16 {
+				w.lavaScar[i]++
+			}
+			continue
+		}
+		if params.LavaRegrowthChance <= 0 || w.streams.vegetation.Float64() >= params.LavaRegrowthChance {
+			continue
+		}
+
+		land := w.landGround(i)
+		w.groundCurr[i] = land
+		w.groundNext[i] = land
+		w.lavaScar[i] = 0
+		if w.vegNext[i] == VegetationNone && maxVegetation(land) > VegetationNone {
+			w.vegNext[i] = VegetationGrass
+			w.vegAge[i] = 0
+			w.vegHealth[i] = 1
+		}
+		w.vegEvents.lavaRegrowth++
+	}
+}
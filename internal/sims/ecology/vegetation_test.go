@@ -0,0 +1,177 @@
+package ecology
+
+import "testing"
+
+// growthOff stops vegetation from spreading, seeding, or dying of age, so
+// tests can drive one process at a time.
+func growthOff(cfg *Config) {
+	cfg.Params.GrassSpreadChance = 0
+	cfg.Params.ShrubGrowthChance = 0
+	cfg.Params.TreeGrowthChance = 0
+	cfg.Params.TreeSeedChance = 0
+	cfg.Params.TreeAgeMortalityChance = 0
+}
+
+// stepVegetation runs only the vegetation pass so tests can isolate it from
+// weather, lava, and fire.
+func stepVegetation(world *World) {
+	world.applyVegetation()
+	world.updateMetrics(world.vegNext)
+	world.vegCurr, world.vegNext = world.vegNext, world.vegCurr
+}
+
+func TestDroughtKillsVegetationAndRecordsCause(t *testing.T) {
+	world := newTestWorld(t, 3, 1, 21, growthOff)
+	for i := range world.vegCurr {
+		world.vegCurr[i] = VegetationGrass
+	}
+	world.soilMoisture[0] = 0
+	world.soilMoisture[1] = 0.1
+	world.soilMoisture[2] = 0.5
+
+	deaths := 0
+	for tick := 0; tick < 60; tick++ {
+		stepVegetation(world)
+		deaths += world.metrics.DroughtDeaths
+	}
+
+	if world.vegCurr[0] != VegetationNone {
+		t.Fatalf("grass on bone-dry soil should die, got %v", world.vegCurr[0])
+	}
+	if world.vegCurr[1] != VegetationGrass || world.vegHealth[1] >= 1 {
+		t.Fatalf("mild drought should only wound grass, got %v health %.3f", world.vegCurr[1], world.vegHealth[1])
+	}
+	if world.vegCurr[2] != VegetationGrass || world.vegHealth[2] != 1 {
+		t.Fatalf("moist soil should keep grass healthy, got %v health %.3f", world.vegCurr[2], world.vegHealth[2])
+	}
+	if world.vegAge[2] != 60 {
+		t.Fatalf("expected surviving grass to be 60 ticks old, got %d", world.vegAge[2])
+	}
+	if deaths != 1 {
+		t.Fatalf("expected exactly one drought death, got %d", deaths)
+	}
+}
+
+func TestCrowdedTreesThinFromTheInside(t *testing.T) {
+	world := newTestWorld(t, 5, 5, 21, growthOff)
+	world.cfg.Params.TreeCrowdingDamage = 0.25
+	for i := range world.vegCurr {
+		world.vegCurr[i] = VegetationTree
+	}
+
+	deaths := 0
+	for tick := 0; tick < 4; tick++ {
+		stepVegetation(world)
+		deaths += world.metrics.CrowdingDeaths
+	}
+
+	if deaths == 0 {
+		t.Fatalf("expected crowding deaths in a closed canopy")
+	}
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5; x++ {
+			idx := y*5 + x
+			edge := x == 0 || y == 0 || x == 4 || y == 4
+			if edge && world.vegCurr[idx] != VegetationTree {
+				t.Fatalf("edge tree at (%d,%d) has open neighbours and should survive", x, y)
+			}
+		}
+	}
+	if world.vegCurr[2*5+2] != VegetationNone {
+		t.Fatalf("centre tree should have died from crowding")
+	}
+}
+
+func TestOldTreesDieOfAge(t *testing.T) {
+	world := newTestWorld(t, 3, 1, 21, growthOff)
+	world.cfg.Params.TreeAgeMortalityStart = 5
+	world.cfg.Params.TreeAgeMortalityChance = 1
+	world.vegCurr[0] = VegetationTree
+	world.vegCurr[2] = VegetationShrub
+
+	for tick := 0; tick < 5; tick++ {
+		stepVegetation(world)
+		if world.vegCurr[0] != VegetationTree {
+			t.Fatalf("tree died before reaching the mortality age at tick %d", tick)
+		}
+	}
+	stepVegetation(world)
+	if world.vegCurr[0] != VegetationNone || world.metrics.AgeDeaths != 1 {
+		t.Fatalf("expected the old tree to die of age, got %v with %d age deaths", world.vegCurr[0], world.metrics.AgeDeaths)
+	}
+	if world.vegCurr[2] != VegetationShrub {
+		t.Fatalf("age mortality should only apply to trees")
+	}
+	if world.vegAge[0] != 0 || world.vegHealth[0] != 1 {
+		t.Fatalf("dead cell should reset age and health, got %d / %.2f", world.vegAge[0], world.vegHealth[0])
+	}
+}
+
+func TestTreeSeedsEstablishSaplingsAtRange(t *testing.T) {
+	world := newTestWorld(t, 21, 21, 21, growthOff)
+	world.cfg.Params.TreeSeedChance = 1
+	world.cfg.Params.TreeSeedDistance = 6
+	world.cfg.Params.TreeSeedWind = 0
+	world.cfg.Params.SoilGrowthDry = 1
+	world.cfg.Params.SoilGrowthWet = 1
+	for i := range world.vegCurr {
+		world.vegCurr[i] = VegetationGrass
+	}
+	centre := 10*21 + 10
+	world.vegCurr[centre] = VegetationTree
+
+	seedlings := 0
+	far := 0
+	for tick := 0; tick < 40; tick++ {
+		stepVegetation(world)
+		seedlings += world.metrics.SeedlingsEstablished
+		for y := 0; y < 21; y++ {
+			for x := 0; x < 21; x++ {
+				idx := y*21 + x
+				if world.vegCurr[idx] != VegetationShrub {
+					continue
+				}
+				dx, dy := x-10, y-10
+				if dx*dx+dy*dy > 7*7 {
+					t.Fatalf("sapling at (%d,%d) landed beyond the seed distance", x, y)
+				}
+				if dx*dx+dy*dy > 2 {
+					far++
+				}
+				world.vegCurr[idx] = VegetationGrass
+			}
+		}
+	}
+
+	if seedlings == 0 || far == 0 {
+		t.Fatalf("expected saplings beyond the neighbour ring, got %d seedlings (%d far)", seedlings, far)
+	}
+}
+
+func TestCooledLavaWeathersBackToGrass(t *testing.T) {
+	world := newTestWorld(t, 2, 1, 21, growthOff)
+	world.cfg.Params.LavaRegrowthDelay = 3
+	world.cfg.Params.LavaRegrowthChance = 1
+	world.cfg.Params.BasaltGrassFactor = 0
+	world.groundCurr[0] = GroundBasalt
+	world.groundCurr[1] = GroundRock
+	world.lavaScar[0] = 1
+
+	for tick := 0; tick < 3; tick++ {
+		stepVegetation(world)
+		if world.groundCurr[0] != GroundBasalt {
+			t.Fatalf("scar weathered before the regrowth delay at tick %d", tick)
+		}
+	}
+	stepVegetation(world)
+
+	if world.groundCurr[0] != GroundDirt || world.vegCurr[0] != VegetationGrass {
+		t.Fatalf("expected cooled lava to regrow grass, got ground %v veg %v", world.groundCurr[0], world.vegCurr[0])
+	}
+	if world.metrics.LavaRegrowth != 1 {
+		t.Fatalf("expected one regrowth event, got %d", world.metrics.LavaRegrowth)
+	}
+	if world.groundCurr[1] != GroundRock {
+		t.Fatalf("ordinary rock should not weather into dirt")
+	}
+}
@@ -0,0 +1,361 @@
+package ecology
+
+import "math"
+
+// weatherFront is a band of low pressure sweeping across the map along its
+// unit normal (nx, ny). offset is the signed distance of the front line from
+// the map centre along the normal.
+type weatherFront struct {
+	nx, ny   float64
+	offset   float64
+	strength float64
+}
+
+// weatherState holds the coarse humidity and pressure grids that rain
+// regions condense from when Params.Weather is enabled. Each grid cell
+// covers cell×cell tiles.
+type weatherState struct {
+	cell   int
+	gw, gh int
+	// torus wraps advection and gradients around the grid edges.
+	torus bool
+
+	humidity     []float32
+	pressure     []float32
+	scratch      []float32
+	windX, windY []float32
+	lift         []float32
+	moisture     []float32
+	elevation    []float32
+
+	fronts []weatherFront
+}
+
+// ensureWeatherGrid sizes the coarse grids for the current WeatherCellSize,
+// starting from half-saturated air at baseline pressure.
+func (w *World) ensureWeatherGrid() bool {
+	cell := max(w.cfg.Params.WeatherCellSize, 1)
+	gw := (w.w + cell - 1) / cell
+	gh := (w.h + cell - 1) / cell
+	ws := &w.weather
+	if ws.cell == cell && ws.gw == gw && ws.gh == gh && ws.torus == w.torus && len(ws.humidity) == gw*gh {
+		return gw*gh > 0
+	}
+	total := gw * gh
+	*ws = weatherState{
+		cell:      cell,
+		gw:        gw,
+		gh:        gh,
+		torus:     w.torus,
+		humidity:  make([]float32, total),
+		pressure:  make([]float32, total),
+		scratch:   make([]float32, total),
+		windX:     make([]float32, total),
+		windY:     make([]float32, total),
+		lift:      make([]float32, total),
+		moisture:  make([]float32, total),
+		elevation: make([]float32, total),
+		fronts:    ws.fronts,
+	}
+	start := float32(w.cfg.Params.WeatherSaturation * 0.5)
+	for i := range ws.humidity {
+		ws.humidity[i] = start
+		ws.pressure[i] = 0.5
+	}
+	return total > 0
+}
+
+// updateWeather replaces the random rain spawner when Params.Weather is on.
+// Humidity evaporates from wet ground and open water, and both humidity and
+// pressure are advected by the wind. Fronts sweep low pressure across the
+// map. Rain regions condense where humidity exceeds a saturation level that
+// drops under low pressure and on windward slopes, and falling rain drains
+// the air beneath it.
+func (w *World) updateWeather() {
+	if w.w <= 0 || w.h <= 0 || !w.ensureWeatherGrid() {
+		return
+	}
+	w.sampleWeatherInputs()
+	w.advanceFronts()
+
+	params := w.cfg.Params
+	ws := &w.weather
+	advectWeather(ws, ws.humidity)
+	advectWeather(ws, ws.pressure)
+
+	evaporation := params.WeatherEvaporation * w.climate.Evaporation
+	relax := clampFloat(params.WeatherPressureRelax, 0, 1)
+	for i := range ws.humidity {
+		cx, cy := w.weatherCellCenter(i)
+		target := 0.5 - w.frontDepthAt(cx, cy)
+		ws.pressure[i] += float32(relax * (target - float64(ws.pressure[i])))
+
+		rain := 0.0
+		if idx := int(cy)*w.w + int(cx); idx < len(w.rainCurr) {
+			rain = float64(w.rainCurr[idx])
+		}
+		humidity := float64(ws.humidity[i]) + evaporation*float64(ws.moisture[i]) - params.WeatherRainout*rain
+		ws.humidity[i] = float32(clampFloat(humidity, 0, 2))
+	}
+
+	w.condenseRainRegions()
+}
+
+// sampleWeatherInputs averages soil moisture, open water, and elevation over
+// each grid cell, samples the wind at cell centres, and derives the
+// orographic lift: the wind speed component running uphill.
+func (w *World) sampleWeatherInputs() {
+	ws := &w.weather
+	for i := range ws.moisture {
+		ws.moisture[i] = 0
+		ws.elevation[i] = 0
+	}
+	for y := 0; y < w.h; y++ {
+		row := (y / ws.cell) * ws.gw
+		for x := 0; x < w.w; x++ {
+			idx := y*w.w + x
+			cell := row + x/ws.cell
+			wet := 0.0
+			if idx < len(w.soilMoisture) {
+				wet = float64(w.soilMoisture[idx])
+			}
+			if w.groundCurr[idx] == GroundWater {
+				wet = 1
+			}
+			ws.moisture[cell] += float32(wet)
+			ws.elevation[cell] += float32(w.lavaElevation[idx])
+		}
+	}
+	for i := range ws.moisture {
+		cx, cy := w.weatherCellCenter(i)
+		x0, y0 := (i%ws.gw)*ws.cell, (i/ws.gw)*ws.cell
+		tiles := float32(max((min(x0+ws.cell, w.w)-x0)*(min(y0+ws.cell, w.h)-y0), 1))
+		ws.moisture[i] /= tiles
+		ws.elevation[i] /= tiles
+		vx, vy := w.windVector(cx, cy)
+		ws.windX[i] = float32(vx)
+		ws.windY[i] = float32(vy)
+	}
+	for i := range ws.lift {
+		gx, gy := i%ws.gw, i/ws.gw
+		left, right := axisNeighbor(gx-1, ws.gw, ws.torus), axisNeighbor(gx+1, ws.gw, ws.torus)
+		up, down := axisNeighbor(gy-1, ws.gh, ws.torus), axisNeighbor(gy+1, ws.gh, ws.torus)
+		dx := (ws.elevation[gy*ws.gw+right] - ws.elevation[gy*ws.gw+left]) / float32(2*ws.cell)
+		dy := (ws.elevation[down*ws.gw+gx] - ws.elevation[up*ws.gw+gx]) / float32(2*ws.cell)
+		ws.lift[i] = ws.windX[i]*dx + ws.windY[i]*dy
+	}
+}
+
+// advectWeather moves field with the cell winds by semi-Lagrangian back
+// tracing, sampling the upwind point bilinearly.
+func advectWeather(ws *weatherState, field []float32) {
+	scale := 1 / float64(ws.cell)
+	for i := range field {
+		x := float64(i%ws.gw) - float64(ws.windX[i])*scale
+		y := float64(i/ws.gw) - float64(ws.windY[i])*scale
+		ws.scratch[i] = ws.sample(field, x, y)
+	}
+	copy(field, ws.scratch)
+}
+
+// sample reads field bilinearly at grid coordinates (x, y), clamping to the
+// grid edges, or wrapping around them on a torus.
+func (ws *weatherState) sample(field []float32, x, y float64) float32 {
+	x0, x1, fx := ws.sampleAxis(x, ws.gw)
+	y0, y1, fy := ws.sampleAxis(y, ws.gh)
+	top := field[y0*ws.gw+x0]*(1-fx) + field[y0*ws.gw+x1]*fx
+	bottom := field[y1*ws.gw+x0]*(1-fx) + field[y1*ws.gw+x1]*fx
+	return top*(1-fy) + bottom*fy
+}
+
+// sampleAxis returns the grid cells bracketing coordinate v on an axis of n
+// cells and the weight of the second.
+func (ws *weatherState) sampleAxis(v float64, n int) (int, int, float32) {
+	if ws.torus {
+		v = math.Mod(v, float64(n))
+		if v < 0 {
+			v += float64(n)
+		}
+		i := min(int(v), n-1)
+		return i, (i + 1) % n, float32(v - float64(i))
+	}
+	v = clampFloat(v, 0, float64(n-1))
+	i := int(v)
+	return i, min(i+1, n-1), float32(v - float64(i))
+}
+
+// weatherCellCenter returns the tile coordinate at the centre of grid cell i,
+// kept inside the map for partial edge cells.
+func (w *World) weatherCellCenter(i int) (float64, float64) {
+	ws := &w.weather
+	x := math.Min(float64((i%ws.gw)*ws.cell)+float64(ws.cell)/2, float64(w.w)-0.5)
+	y := math.Min(float64((i/ws.gw)*ws.cell)+float64(ws.cell)/2, float64(w.h)-0.5)
+	return x, y
+}
+
+// advanceFronts moves every front along its normal, retires fronts that have
+// crossed the map, and spawns new ones on the upwind edge.
+func (w *World) advanceFronts() {
+	params := w.cfg.Params
+	ws := &w.weather
+	reach := math.Hypot(float64(w.w), float64(w.h))/2 + params.WeatherFrontWidth
+	active := ws.fronts[:0]
+	for _, front := range ws.fronts {
+		front.offset += params.WeatherFrontSpeed
+		if front.offset <= reach {
+			active = append(active, front)
+		}
+	}
+	ws.fronts = active
+
+	if len(ws.fronts) >= params.WeatherFrontMax || params.WeatherFrontChance <= 0 {
+		return
+	}
+	if w.streams.rain.Float64() >= clamp01(params.WeatherFrontChance*w.climate.Rain) {
+		return
+	}
+	vx, vy := w.windVector(float64(w.w)/2, float64(w.h)/2)
+	angle := math.Atan2(vy, vx)
+	if math.Hypot(vx, vy) < 1e-3 {
+		angle = w.streams.rain.Float64() * 2 * math.Pi
+	}
+	ws.fronts = append(ws.fronts, weatherFront{
+		nx:       math.Cos(angle),
+		ny:       math.Sin(angle),
+		offset:   -reach,
+		strength: 0.6 + 0.4*w.streams.rain.Float64(),
+	})
+}
+
+// frontDepthAt sums the pressure drop of every front at tile (x, y). Each
+// front is a Gaussian trough WeatherFrontWidth tiles wide.
+func (w *World) frontDepthAt(x, y float64) float64 {
+	params := w.cfg.Params
+	width := math.Max(params.WeatherFrontWidth, 1)
+	depth := 0.0
+	for _, front := range w.weather.fronts {
+		d := w.frontDistance(front, x, y) / width
+		depth += params.WeatherFrontDepth * front.strength * math.Exp(-d*d)
+	}
+	return depth
+}
+
+// frontDistance is the signed distance from (x, y) to the front line,
+// positive ahead of the front.
+func (w *World) frontDistance(front weatherFront, x, y float64) float64 {
+	return (x-float64(w.w)/2)*front.nx + (y-float64(w.h)/2)*front.ny - front.offset
+}
+
+// weatherSaturation is the humidity above which grid cell i condenses.
+// Pressure below the 0.5 baseline and orographic lift both lower it.
+func (w *World) weatherSaturation(i int) float64 {
+	params := w.cfg.Params
+	ws := &w.weather
+	pressure := float64(ws.pressure[i])
+	lift := math.Max(float64(ws.lift[i]), 0)
+	return params.WeatherSaturation*(0.5+pressure) - params.WeatherOrographicLift*lift
+}
+
+// condenseRainRegions spawns up to two rain regions per tick, like
+// spawnRainRegion, but centred on the most supersaturated dry cells instead
+// of random tiles. Regions condensing on a front are stratus bands laid
+// along it. Condensing spends the cell's excess humidity.
+func (w *World) condenseRainRegions() {
+	params := w.cfg.Params
+	ws := &w.weather
+	maxRegions := params.RainMaxRegions
+	chance := clamp01(params.WeatherCondenseChance * w.climate.Rain)
+	for attempt := 0; attempt < 2 && len(w.rainRegions) < maxRegions; attempt++ {
+		best, bestExcess := -1, 0.0
+		for i := range ws.humidity {
+			excess := float64(ws.humidity[i]) - w.weatherSaturation(i)
+			if excess <= bestExcess {
+				continue
+			}
+			cx, cy := w.weatherCellCenter(i)
+			if idx := int(cy)*w.w + int(cx); idx < len(w.rainCurr) && w.rainCurr[idx] > 0.05 {
+				continue
+			}
+			best, bestExcess = i, excess
+		}
+		if best < 0 || w.streams.rain.Float64() >= chance {
+			return
+		}
+
+		region := w.makeRainRegion()
+		region.cx, region.cy = w.weatherCellCenter(best)
+		if front, ok := w.nearestFront(region.cx, region.cy); ok {
+			region.preset = rainPresetStratus
+			region.angle = math.Atan2(front.nx, -front.ny)
+			region.radiusX = math.Max(region.radiusX, region.radiusY*2)
+			region.targetRadiusX = region.radiusX
+		}
+		w.rainRegions = append(w.rainRegions, region)
+		ws.humidity[best] -= float32(bestExcess)
+	}
+}
+
+// nearestFront reports the front whose trough covers (x, y), if any.
+func (w *World) nearestFront(x, y float64) (weatherFront, bool) {
+	width := math.Max(w.cfg.Params.WeatherFrontWidth, 1)
+	for _, front := range w.weather.fronts {
+		if math.Abs(w.frontDistance(front, x, y)) <= width {
+			return front, true
+		}
+	}
+	return weatherFront{}, false
+}
+
+// addOrographicRain lays drizzle on windward slopes in proportion to the
+// wind running uphill and the humidity of the air, before the rain mask is
+// smoothed. Leeward slopes stay dry, leaving a rain shadow.
+func (w *World) addOrographicRain() {
+	params := w.cfg.Params
+	ws := &w.weather
+	if !params.Weather || params.WeatherOrographicRain <= 0 || len(ws.humidity) == 0 {
+		return
+	}
+	for y := 0; y < w.h; y++ {
+		up, down := axisNeighbor(y-1, w.h, w.torus), axisNeighbor(y+1, w.h, w.torus)
+		for x := 0; x < w.w; x++ {
+			cell := (y/ws.cell)*ws.gw + x/ws.cell
+			left, right := axisNeighbor(x-1, w.w, w.torus), axisNeighbor(x+1, w.w, w.torus)
+			dx := float64(w.lavaElevation[y*w.w+right]-w.lavaElevation[y*w.w+left]) / 2
+			dy := float64(w.lavaElevation[down*w.w+x]-w.lavaElevation[up*w.w+x]) / 2
+			lift := float64(ws.windX[cell])*dx + float64(ws.windY[cell])*dy
+			if lift <= 0 {
+				continue
+			}
+			val := float32(clamp01(params.WeatherOrographicRain * lift * float64(ws.humidity[cell])))
+			idx := y*w.w + x
+			if val > w.rainNext[idx] {
+				w.rainNext[idx] = val
+			}
+		}
+	}
+}
+
+// weatherField upsamples a coarse weather grid to tile resolution.
+func (w *World) weatherField(dst []float32, field []float32) []float32 {
+	total := w.w * w.h
+	if len(dst) != total {
+		dst = make([]float32, total)
+	}
+	for y := 0; y < w.h; y++ {
+		for x := 0; x < w.w; x++ {
+			dst[y*w.w+x] = w.weatherAt(field, x, y)
+		}
+	}
+	return dst
+}
+
+// weatherAt samples a coarse weather field at the centre of tile (x, y),
+// reading 0 while the weather grid is not allocated.
+func (w *World) weatherAt(field []float32, x, y int) float32 {
+	ws := &w.weather
+	if len(field) == 0 || ws.gw*ws.gh != len(field) {
+		return 0
+	}
+	scale := 1 / float64(ws.cell)
+	return ws.sample(field, (float64(x)+0.5)*scale-0.5, (float64(y)+0.5)*scale-0.5)
+}
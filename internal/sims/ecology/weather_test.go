@@ -0,0 +1,159 @@
+package ecology
+
+import "testing"
+
+// withWeather switches weather systems on.
+func withWeather(cfg *Config) {
+	cfg.Params.Weather = true
+}
+
+// requireWeatherGrid allocates the weather grid so its passes can run on
+// their own, outside Step.
+func requireWeatherGrid(t *testing.T, world *World) {
+	t.Helper()
+	if !world.ensureWeatherGrid() {
+		t.Fatalf("expected a weather grid for a %dx%d world", world.w, world.h)
+	}
+}
+
+func TestWeatherAdvectsHumidityDownwind(t *testing.T) {
+	world := newTestWorld(t, 64, 8, 31, withWeather)
+	requireWeatherGrid(t, world)
+	ws := &world.weather
+	for i := range ws.humidity {
+		ws.humidity[i] = 0
+		ws.windX[i] = float32(ws.cell)
+		ws.windY[i] = 0
+	}
+	ws.humidity[2] = 1
+
+	advectWeather(ws, ws.humidity)
+
+	if ws.humidity[3] != 1 || ws.humidity[2] != 0 {
+		t.Fatalf("expected the moist cell to move one cell downwind, got %v", ws.humidity)
+	}
+}
+
+func TestWeatherFrontsSweepAcrossMap(t *testing.T) {
+	world := newTestWorld(t, 64, 64, 31, withWeather)
+	requireWeatherGrid(t, world)
+	world.cfg.Params.WeatherFrontChance = 1
+	world.cfg.Params.WeatherFrontMax = 1
+	world.cfg.Params.WeatherFrontSpeed = 4
+	world.cfg.Params.WeatherPressureRelax = 1
+
+	world.advanceFronts()
+	if len(world.weather.fronts) != 1 {
+		t.Fatalf("expected a front to spawn, got %d", len(world.weather.fronts))
+	}
+	start := world.weather.fronts[0].offset
+
+	sawTrough := false
+	for tick := 0; tick < 200; tick++ {
+		world.updateWeather()
+		world.cfg.Params.WeatherFrontChance = 0
+		if len(world.weather.fronts) == 0 {
+			break
+		}
+		front := world.weather.fronts[0]
+		if front.offset < start {
+			t.Fatalf("front moved backwards: %v -> %v", start, front.offset)
+		}
+		// Sample the pressure on the front line near the map centre.
+		cx, cy := 32+front.nx*front.offset, 32+front.ny*front.offset
+		if cx >= 0 && cx < 64 && cy >= 0 && cy < 64 {
+			cell := int(cy)/world.weather.cell*world.weather.gw + int(cx)/world.weather.cell
+			if world.weather.pressure[cell] < 0.45 {
+				sawTrough = true
+			}
+		}
+	}
+	if !sawTrough {
+		t.Fatalf("expected the front to drag a pressure trough across the map")
+	}
+	if len(world.weather.fronts) != 0 {
+		t.Fatalf("expected the front to leave the map, still at %v", world.weather.fronts[0].offset)
+	}
+}
+
+func TestSaturatedAirCondensesRainRegions(t *testing.T) {
+	world := newTestWorld(t, 64, 64, 31, withWeather)
+	requireWeatherGrid(t, world)
+	world.cfg.Params.WeatherCondenseChance = 1
+	world.cfg.Params.WeatherFrontChance = 0
+	world.rainRegions = nil
+
+	world.updateWeather()
+	if len(world.rainRegions) != 0 {
+		t.Fatalf("dry air should not condense, got %d regions", len(world.rainRegions))
+	}
+
+	ws := &world.weather
+	moist := 5*ws.gw + 5
+	ws.humidity[moist] = 2
+	world.condenseRainRegions()
+	if len(world.rainRegions) == 0 {
+		t.Fatalf("expected the saturated cell to condense a rain region")
+	}
+	cx, cy := world.weatherCellCenter(moist)
+	if region := world.rainRegions[0]; region.cx != cx || region.cy != cy {
+		t.Fatalf("expected the region at the saturated cell (%v,%v), got (%v,%v)", cx, cy, region.cx, region.cy)
+	}
+	if float64(ws.humidity[moist]) > world.weatherSaturation(moist)+1e-6 {
+		t.Fatalf("condensing should spend the excess humidity, left %v", ws.humidity[moist])
+	}
+}
+
+func TestOrographicRainFallsOnWindwardSlopes(t *testing.T) {
+	world := newTestWorld(t, 32, 8, 31, withWeather)
+	requireWeatherGrid(t, world)
+	ws := &world.weather
+	for y := 0; y < world.h; y++ {
+		for x := 0; x < world.w; x++ {
+			// A ridge peaking at x=16.
+			world.lavaElevation[y*world.w+x] = int16(16 - max(x-16, 16-x))
+		}
+	}
+	for i := range ws.humidity {
+		ws.humidity[i] = 1
+		ws.windX[i] = 1
+		ws.windY[i] = 0
+	}
+	for i := range world.rainNext {
+		world.rainNext[i] = 0
+	}
+
+	world.addOrographicRain()
+
+	row := 4 * world.w
+	if world.rainNext[row+8] <= 0 {
+		t.Fatalf("expected rain on the windward slope")
+	}
+	if world.rainNext[row+24] != 0 {
+		t.Fatalf("expected a dry rain shadow on the leeward slope, got %v", world.rainNext[row+24])
+	}
+
+	world.cfg.Params.Weather = false
+	world.rainNext[row+8] = 0
+	world.addOrographicRain()
+	if world.rainNext[row+8] != 0 {
+		t.Fatalf("orographic rain should be off without weather systems")
+	}
+}
+
+func TestWeatherDisabledKeepsRandomRainSpawner(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Width = 32
+	cfg.Height = 32
+	world := NewWithConfig(cfg)
+	world.Reset(0)
+	for tick := 0; tick < 20; tick++ {
+		world.Step()
+	}
+	if len(world.weather.humidity) != 0 || len(world.weather.fronts) != 0 {
+		t.Fatalf("weather state should stay idle when disabled")
+	}
+	if humidity := world.Field("humidity"); len(humidity) != 32*32 || humidity[0] != 0 {
+		t.Fatalf("expected an empty humidity overlay when disabled")
+	}
+}
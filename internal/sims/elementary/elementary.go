@@ -48,6 +48,8 @@ type Elementary struct {
 	rule uint8
 	cur  []uint8
 	tmp  []uint8
+
+	field []float32
 }
 
 // New creates an automaton with the given dimensions and rule.
@@ -90,9 +92,25 @@ func (e *Elementary) Step() {
 	}
 }
 
+// Fields lists the exported per-cell layers.
+func (e *Elementary) Fields() []core.FieldInfo {
+	return []core.FieldInfo{
+		{Key: "state", Label: "State", Type: core.FieldTypeBool, Min: 0, Max: 1, Colormap: "grayscale", Dense: true},
+	}
+}
+
+// Field returns the named layer as float32 values.
+func (e *Elementary) Field(key string) []float32 {
+	if key != "state" {
+		return nil
+	}
+	e.field = core.FieldFromUint8(e.field, e.cur)
+	return e.field
+}
+
 func init() {
-	core.Register("elementary", func(cfg map[string]string) core.Sim {
+	core.Register("elementary", func(cfg map[string]string) (core.Sim, error) {
 		c := FromMap(cfg)
-		return New(c.Width, c.Height, c.Rule)
+		return New(c.Width, c.Height, c.Rule), nil
 	})
 }
@@ -9,6 +9,8 @@ type Life struct {
 	w, h int
 	cur  []uint8
 	nxt  []uint8
+
+	field []float32
 }
 
 // New returns a Life simulation with the provided dimensions.
@@ -59,9 +61,40 @@ func (l *Life) Step() {
 	l.cur, l.nxt = l.nxt, l.cur
 }
 
+// MetricsSnapshot reports the live cell population for charting.
+func (l *Life) MetricsSnapshot() core.MetricsSnapshot {
+	population := 0
+	for _, c := range l.cur {
+		if c != 0 {
+			population++
+		}
+	}
+	return core.MetricsSnapshot{
+		Samples: []core.MetricSample{
+			{Key: "population", Label: "Alive", Group: "Population", Value: float64(population)},
+		},
+	}
+}
+
+// Fields lists the exported per-cell layers.
+func (l *Life) Fields() []core.FieldInfo {
+	return []core.FieldInfo{
+		{Key: "alive", Label: "Alive", Type: core.FieldTypeBool, Min: 0, Max: 1, Colormap: "grayscale", Dense: true},
+	}
+}
+
+// Field returns the named layer as float32 values.
+func (l *Life) Field(key string) []float32 {
+	if key != "alive" {
+		return nil
+	}
+	l.field = core.FieldFromUint8(l.field, l.cur)
+	return l.field
+}
+
 func init() {
-	core.Register("life", func(cfg map[string]string) core.Sim {
+	core.Register("life", func(cfg map[string]string) (core.Sim, error) {
 		c := FromMap(cfg)
-		return New(c.Width, c.Height)
+		return New(c.Width, c.Height), nil
 	})
 }
@@ -0,0 +1,103 @@
+//go:build ebiten
+
+package ui
+
+import "image/color"
+
+type colorStop struct {
+	t   float64
+	col color.RGBA
+}
+
+// colormap is a piecewise-linear colour ramp sampled over [0,1].
+type colormap struct {
+	name  string
+	stops []colorStop
+}
+
+func (c colormap) at(t float64) color.RGBA {
+	t = clamp01(t)
+	stops := c.stops
+	for i := 1; i < len(stops); i++ {
+		curr := stops[i]
+		if t <= curr.t {
+			prev := stops[i-1]
+			span := curr.t - prev.t
+			var local float64
+			if span > 0 {
+				local = (t - prev.t) / span
+			}
+			return lerpRGBA(prev.col, curr.col, clamp01(local))
+		}
+	}
+	return stops[len(stops)-1].col
+}
+
+// selectableColormaps are the ramps users can cycle through on any layer, in
+// addition to the layer's own default.
+var selectableColormaps = []colormap{viridisColormap, magmaColormap, grayscaleColormap}
+
+var namedColormaps = map[string]colormap{
+	"viridis":   viridisColormap,
+	"magma":     magmaColormap,
+	"grayscale": grayscaleColormap,
+	"rain":      rainColormap,
+	"volcano":   volcanoColormap,
+	"elevation": elevationColormap,
+	"heat":      heatColormap,
+}
+
+// lookupColormap resolves a colormap by name, falling back to viridis.
+func lookupColormap(name string) colormap {
+	if c, ok := namedColormaps[name]; ok {
+		return c
+	}
+	return viridisColormap
+}
+
+var viridisColormap = colormap{name: "viridis", stops: []colorStop{
+	{0.0, color.RGBA{R: 68, G: 1, B: 84, A: 255}},
+	{0.25, color.RGBA{R: 59, G: 82, B: 139, A: 255}},
+	{0.5, color.RGBA{R: 33, G: 145, B: 140, A: 255}},
+	{0.75, color.RGBA{R: 94, G: 201, B: 98, A: 255}},
+	{1.0, color.RGBA{R: 253, G: 231, B: 37, A: 255}},
+}}
+
+var magmaColormap = colormap{name: "magma", stops: []colorStop{
+	{0.0, color.RGBA{R: 0, G: 0, B: 4, A: 255}},
+	{0.25, color.RGBA{R: 81, G: 18, B: 124, A: 255}},
+	{0.5, color.RGBA{R: 183, G: 55, B: 121, A: 255}},
+	{0.75, color.RGBA{R: 252, G: 137, B: 97, A: 255}},
+	{1.0, color.RGBA{R: 252, G: 253, B: 191, A: 255}},
+}}
+
+var grayscaleColormap = colormap{name: "grayscale", stops: []colorStop{
+	{0.0, color.RGBA{R: 0, G: 0, B: 0, A: 255}},
+	{1.0, color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+}}
+
+var rainColormap = colormap{name: "rain", stops: []colorStop{
+	{0.0, color.RGBA{R: 22, G: 57, B: 78, A: 255}},
+	{1.0, color.RGBA{R: 64, G: 164, B: 223, A: 255}},
+}}
+
+var volcanoColormap = colormap{name: "volcano", stops: []colorStop{
+	{0.0, color.RGBA{R: 89, G: 42, B: 14, A: 255}},
+	{1.0, color.RGBA{R: 255, G: 120, B: 40, A: 255}},
+}}
+
+var elevationColormap = colormap{name: "elevation", stops: []colorStop{
+	{0.0, color.RGBA{R: 40, G: 60, B: 120, A: 255}},
+	{0.25, color.RGBA{R: 70, G: 105, B: 160, A: 255}},
+	{0.5, color.RGBA{R: 90, G: 150, B: 100, A: 255}},
+	{0.75, color.RGBA{R: 190, G: 160, B: 80, A: 255}},
+	{1.0, color.RGBA{R: 240, G: 235, B: 215, A: 255}},
+}}
+
+var heatColormap = colormap{name: "heat", stops: []colorStop{
+	{0.0, color.RGBA{R: 30, G: 0, B: 40, A: 255}},
+	{0.3, color.RGBA{R: 110, G: 20, B: 80, A: 255}},
+	{0.55, color.RGBA{R: 200, G: 60, B: 35, A: 255}},
+	{0.8, color.RGBA{R: 255, G: 140, B: 30, A: 255}},
+	{1.0, color.RGBA{R: 255, G: 235, B: 200, A: 255}},
+}}
@@ -7,8 +7,10 @@ import (
 	"image"
 	"image/color"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"mad-ca/internal/core"
 
@@ -33,6 +35,13 @@ type HUD struct {
 	controls      []hudControlState
 	intSetter     core.IntParameterSetter
 	floatSetter   core.FloatParameterSetter
+	stringSetter  core.StringParameterSetter
+	boolSetter    core.BoolParameterSetter
+	statusSource  core.StatusProvider
+	status        []core.NamedValue
+	profiler      core.StepProfiler
+	profile       []string
+	showProfile   bool
 	panelOffsetX  int
 	title         string
 	scrollOffset  int
@@ -52,6 +61,13 @@ func NewHUD(sim core.Sim, width int) *HUD {
 		h.pixel.Fill(color.White)
 	}
 	h.title = buildTitle(sim)
+	if provider, ok := sim.(core.StatusProvider); ok {
+		h.statusSource = provider
+		h.status = provider.Status()
+	}
+	if profiler, ok := sim.(core.StepProfiler); ok {
+		h.profiler = profiler
+	}
 	if provider, ok := sim.(core.ParameterControlsProvider); ok {
 		controls := provider.ParameterControls()
 		h.controls = make([]hudControlState, len(controls))
@@ -66,6 +82,12 @@ func NewHUD(sim core.Sim, width int) *HUD {
 	if setter, ok := sim.(core.FloatParameterSetter); ok {
 		h.floatSetter = setter
 	}
+	if setter, ok := sim.(core.BoolParameterSetter); ok {
+		h.boolSetter = setter
+	}
+	if setter, ok := sim.(core.StringParameterSetter); ok {
+		h.stringSetter = setter
+	}
 	return h
 }
 
@@ -76,6 +98,19 @@ func (h *HUD) Update(panelOffsetX int) {
 		return
 	}
 	h.panelOffsetX = panelOffsetX
+	if h.profiler != nil && inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		h.showProfile = !h.showProfile
+	}
+	if h.statusSource != nil || h.profiler != nil {
+		previous := len(h.status) + len(h.profile)
+		if h.statusSource != nil {
+			h.status = h.statusSource.Status()
+		}
+		h.profile = h.profileLines()
+		if len(h.status)+len(h.profile) != previous {
+			h.layoutControls()
+		}
+	}
 	provider, ok := h.sim.(parameterProvider)
 	if !ok {
 		h.snapshot = core.ParameterSnapshot{}
@@ -164,12 +199,25 @@ func (h *HUD) refreshControlValues() {
 				continue
 			}
 			value := parsed
-			if isChanceControl(state.control) {
+			if state.control.Percent {
 				value = parsed * 100
 			}
 			state.floatValue = value
 			state.value = h.formatFloat(state, value)
 			state.hasValue = true
+		case core.ParamTypeBool:
+			parsed, err := strconv.ParseBool(param.Value)
+			if err != nil {
+				state.hasValue = false
+				state.value = "--"
+				continue
+			}
+			state.boolValue = parsed
+			state.value = onOff(parsed)
+			state.hasValue = true
+		case core.ParamTypeString:
+			state.value = param.Value
+			state.hasValue = len(state.control.Options) > 0
 		default:
 			state.hasValue = false
 			state.value = "--"
@@ -270,9 +318,52 @@ func (h *HUD) applyAdjustment(state *hudControlState, direction int) {
 			state.floatValue = target
 			state.value = h.formatFloat(state, target)
 		}
+	case core.ParamTypeString:
+		if h.stringSetter == nil {
+			return
+		}
+		target := optionIndex(state) + direction
+		if target < 0 || target >= len(state.control.Options) {
+			return
+		}
+		option := state.control.Options[target]
+		if h.stringSetter.SetStringParameter(state.control.Key, option) {
+			state.value = option
+		}
+	case core.ParamTypeBool:
+		if h.boolSetter == nil {
+			return
+		}
+		target := direction > 0
+		if target == state.boolValue {
+			return
+		}
+		if h.boolSetter.SetBoolParameter(state.control.Key, target) {
+			state.boolValue = target
+			state.value = onOff(target)
+		}
 	}
 }
 
+// onOff labels a bool control's value.
+func onOff(value bool) string {
+	if value {
+		return "on"
+	}
+	return "off"
+}
+
+// optionIndex locates the current value among a string control's options,
+// returning -1 when the value is not one of them.
+func optionIndex(state *hudControlState) int {
+	for i, option := range state.control.Options {
+		if option == state.value {
+			return i
+		}
+	}
+	return -1
+}
+
 func (h *HUD) drawControls() {
 	if h.panel == nil {
 		return
@@ -280,6 +371,14 @@ func (h *HUD) drawControls() {
 	face := basicfont.Face7x13
 	headerY := panelPadding + headerBaseline
 	text.Draw(h.panel, h.title, face, panelPadding, headerY, color.RGBA{R: 200, G: 200, B: 210, A: 255})
+	statusY := h.titleBottom() + statusBaseline
+	for i, item := range h.status {
+		text.Draw(h.panel, item.Name+": "+item.Value, face, panelPadding, statusY+i*statusLineHeight, color.RGBA{R: 180, G: 200, B: 220, A: 255})
+	}
+	profileY := statusY + len(h.status)*statusLineHeight
+	for i, line := range h.profile {
+		text.Draw(h.panel, line, face, panelPadding, profileY+i*statusLineHeight, color.RGBA{R: 200, G: 190, B: 150, A: 255})
+	}
 	if len(h.controls) == 0 {
 		infoY := h.controlsTop() + emptyControlsOffset
 		text.Draw(h.panel, "No adjustable parameters", face, panelPadding, infoY, color.RGBA{R: 160, G: 160, B: 170, A: 255})
@@ -361,6 +460,14 @@ func (h *HUD) canAdjust(state *hudControlState, direction int) bool {
 			return false
 		}
 		return true
+	case core.ParamTypeString:
+		if h.stringSetter == nil {
+			return false
+		}
+		target := optionIndex(state) + direction
+		return target >= 0 && target < len(state.control.Options)
+	case core.ParamTypeBool:
+		return h.boolSetter != nil && (direction > 0) != state.boolValue
 	default:
 		return false
 	}
@@ -391,13 +498,41 @@ func (h *HUD) drawButton(rect image.Rectangle, label string, enabled bool) {
 	text.Draw(h.panel, label, face, x, y, fg)
 }
 
-func (h *HUD) controlsTop() int {
+func (h *HUD) titleBottom() int {
 	if strings.TrimSpace(h.title) == "" {
 		return panelPadding
 	}
 	return panelPadding + headerBaseline + headerGap
 }
 
+func (h *HUD) controlsTop() int {
+	top := h.titleBottom()
+	if lines := len(h.status) + len(h.profile); lines > 0 {
+		top += lines*statusLineHeight + headerGap
+	}
+	return top
+}
+
+// profileLines formats the rolling step phase timings, slowest first, under
+// a total line. It returns nil while the profile is hidden.
+func (h *HUD) profileLines() []string {
+	if !h.showProfile || h.profiler == nil {
+		return nil
+	}
+	timings := h.profiler.PhaseTimings()
+	var total time.Duration
+	for _, timing := range timings {
+		total += timing.Average
+	}
+	sort.SliceStable(timings, func(i, j int) bool { return timings[i].Average > timings[j].Average })
+	lines := make([]string, 0, len(timings)+1)
+	lines = append(lines, fmt.Sprintf("%-14s %6.2fms", "Step", total.Seconds()*1000))
+	for _, timing := range timings {
+		lines = append(lines, fmt.Sprintf("  %-12s %6.2fms", timing.Name, timing.Average.Seconds()*1000))
+	}
+	return lines
+}
+
 func (h *HUD) layoutControls() {
 	controlsStart := h.controlsTop()
 	if len(h.controls) == 0 || h.width <= 0 {
@@ -493,13 +628,6 @@ func (h *HUD) floatStep(state *hudControlState) float64 {
 	return 0.01
 }
 
-func isChanceControl(ctrl core.ParameterControl) bool {
-	if ctrl.Key == "" {
-		return false
-	}
-	return strings.Contains(ctrl.Key, "chance")
-}
-
 func pointInRect(x, y int, rect image.Rectangle) bool {
 	return x >= rect.Min.X && x < rect.Max.X && y >= rect.Min.Y && y < rect.Max.Y
 }
@@ -510,6 +638,7 @@ type hudControlState struct {
 
 	intValue   int
 	floatValue float64
+	boolValue  bool
 	hasValue   bool
 
 	top       int
@@ -528,6 +657,8 @@ const (
 	buttonRowTop        = 28
 	headerGap           = 14
 	emptyControlsOffset = 54
+	statusLineHeight    = 16
+	statusBaseline      = 12
 	scrollStep          = 24
 )
 
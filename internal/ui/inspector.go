@@ -0,0 +1,211 @@
+//go:build ebiten
+
+package ui
+
+import (
+	"image/color"
+	"strconv"
+
+	"mad-ca/internal/core"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// Inspector shows a tooltip describing the cell under the mouse cursor. Rows
+// come from the sim's exported fields, refined by core.CellInspector where the
+// sim provides one; other sims fall back to the raw cell state. The tooltip is
+// toggled with the I key.
+type Inspector struct {
+	sim     core.Sim
+	scale   int
+	enabled bool
+
+	hovering bool
+	cellX    int
+	cellY    int
+	cursorX  int
+	cursorY  int
+
+	pixel *ebiten.Image
+}
+
+// NewInspector constructs an inspector for the provided simulation.
+func NewInspector(sim core.Sim, scale int) *Inspector {
+	if scale <= 0 {
+		scale = 1
+	}
+	in := &Inspector{sim: sim, scale: scale, enabled: true}
+	in.pixel = ebiten.NewImage(1, 1)
+	in.pixel.Fill(color.White)
+	return in
+}
+
+// Update toggles the inspector and tracks the hovered cell.
+func (in *Inspector) Update() {
+	if in == nil {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		in.enabled = !in.enabled
+	}
+	mx, my := ebiten.CursorPosition()
+	size := in.sim.Size()
+	in.cursorX, in.cursorY = mx, my
+	in.cellX, in.cellY = mx/in.scale, my/in.scale
+	in.hovering = mx >= 0 && my >= 0 && in.cellX < size.W && in.cellY < size.H
+}
+
+// Draw paints the tooltip next to the cursor when it hovers over the grid.
+func (in *Inspector) Draw(screen *ebiten.Image) {
+	if in == nil || !in.enabled || !in.hovering {
+		return
+	}
+	rows := in.rows()
+	if len(rows) == 0 {
+		return
+	}
+
+	face := basicfont.Face7x13
+	title := "(" + strconv.Itoa(in.cellX) + ", " + strconv.Itoa(in.cellY) + ")"
+	nameWidth := 0
+	for _, row := range rows {
+		if w := text.BoundString(face, row.Name).Dx(); w > nameWidth {
+			nameWidth = w
+		}
+	}
+	width := text.BoundString(face, title).Dx()
+	for _, row := range rows {
+		if w := nameWidth + inspectorColumnGap + text.BoundString(face, row.Value).Dx(); w > width {
+			width = w
+		}
+	}
+	width += 2 * inspectorPadding
+	height := 2*inspectorPadding + inspectorLineHeight*(len(rows)+1)
+
+	// Keep the tooltip inside the window, flipping to the other side of the
+	// cursor when it would overflow.
+	bounds := screen.Bounds()
+	x := in.cursorX + inspectorCursorGap
+	if x+width > bounds.Max.X {
+		x = in.cursorX - inspectorCursorGap - width
+	}
+	y := in.cursorY + inspectorCursorGap
+	if y+height > bounds.Max.Y {
+		y = in.cursorY - inspectorCursorGap - height
+	}
+	if x < bounds.Min.X {
+		x = bounds.Min.X
+	}
+	if y < bounds.Min.Y {
+		y = bounds.Min.Y
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(float64(width), float64(height))
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorM.Scale(10.0/255.0, 10.0/255.0, 14.0/255.0, 220.0/255.0)
+	screen.DrawImage(in.pixel, op)
+
+	baseline := y + inspectorPadding + inspectorBaseline
+	text.Draw(screen, title, face, x+inspectorPadding, baseline, color.RGBA{R: 230, G: 230, B: 240, A: 255})
+	for _, row := range rows {
+		baseline += inspectorLineHeight
+		text.Draw(screen, row.Name, face, x+inspectorPadding, baseline, color.RGBA{R: 150, G: 150, B: 165, A: 255})
+		text.Draw(screen, row.Value, face, x+inspectorPadding+nameWidth+inspectorColumnGap, baseline, color.RGBA{R: 230, G: 230, B: 240, A: 255})
+	}
+}
+
+// rows lists every exported field at the hovered cell, substituting rows the
+// sim's CellInspector reports under the same name and appending any extras.
+// Sims exposing neither fall back to the raw cell state. Fields are read one
+// cell at a time through core.FieldSampler when the sim provides it.
+func (in *Inspector) rows() []core.NamedValue {
+	var extras []core.NamedValue
+	if inspector, ok := in.sim.(core.CellInspector); ok {
+		extras = inspector.InspectCell(in.cellX, in.cellY)
+	}
+	size := in.sim.Size()
+	idx := in.cellY*size.W + in.cellX
+
+	provider, ok := in.sim.(core.FieldProvider)
+	if !ok {
+		if extras != nil {
+			return extras
+		}
+		cells := in.sim.Cells()
+		if idx < 0 || idx >= len(cells) {
+			return nil
+		}
+		return []core.NamedValue{{Name: "State", Value: strconv.Itoa(int(cells[idx]))}}
+	}
+
+	sampler, _ := in.sim.(core.FieldSampler)
+	used := make([]bool, len(extras))
+	var rows []core.NamedValue
+	for _, info := range provider.Fields() {
+		row := core.NamedValue{Name: info.Label}
+		substituted := false
+		for i, extra := range extras {
+			if !used[i] && extra.Name == info.Label {
+				row.Value = extra.Value
+				used[i] = true
+				substituted = true
+				break
+			}
+		}
+		if !substituted {
+			value, ok := fieldAt(provider, sampler, info.Key, in.cellX, in.cellY, idx)
+			if !ok {
+				continue
+			}
+			row.Value = formatFieldValue(info, value)
+		}
+		rows = append(rows, row)
+	}
+	for i, extra := range extras {
+		if !used[i] {
+			rows = append(rows, extra)
+		}
+	}
+	return rows
+}
+
+// fieldAt reads one cell of a layer, through the sampler when there is one
+// and from the full field otherwise.
+func fieldAt(provider core.FieldProvider, sampler core.FieldSampler, key string, x, y, idx int) (float32, bool) {
+	if sampler != nil {
+		return sampler.FieldAt(key, x, y)
+	}
+	values := provider.Field(key)
+	if idx < 0 || idx >= len(values) {
+		return 0, false
+	}
+	return values[idx], true
+}
+
+func formatFieldValue(info core.FieldInfo, v float32) string {
+	var out string
+	switch info.Type {
+	case core.FieldTypeBool:
+		out = strconv.FormatBool(v != 0)
+	case core.FieldTypeFloat32:
+		out = strconv.FormatFloat(float64(v), 'f', 3, 32)
+	default:
+		out = strconv.Itoa(int(v))
+	}
+	if info.Units != "" {
+		out += " " + info.Units
+	}
+	return out
+}
+
+const (
+	inspectorPadding    = 6
+	inspectorLineHeight = 14
+	inspectorBaseline   = 10
+	inspectorColumnGap  = 10
+	inspectorCursorGap  = 14
+)
@@ -0,0 +1,17 @@
+//go:build !ebiten
+
+package ui
+
+import "mad-ca/internal/core"
+
+// Inspector is a no-op placeholder for headless builds.
+type Inspector struct{}
+
+// NewInspector returns nil in the headless build.
+func NewInspector(core.Sim, int) *Inspector { return nil }
+
+// Update is a no-op in the headless build.
+func (in *Inspector) Update() {}
+
+// Draw is a no-op in the headless build.
+func (in *Inspector) Draw(any) {}
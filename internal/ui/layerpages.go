@@ -0,0 +1,53 @@
+package ui
+
+// layersPerPage is the number of overlay layers the digit keys reach at once.
+const layersPerPage = 9
+
+// layerPages maps the digit keys onto pages of overlay layers, so a sim can
+// export any number of fields and each stays reachable: turning the page
+// moves keys 1–9 on to the next nine layers.
+type layerPages struct {
+	page int
+}
+
+// count reports how many pages n layers fill. An empty list still has one.
+func (p *layerPages) count(n int) int {
+	return max(1, (n+layersPerPage-1)/layersPerPage)
+}
+
+// turn moves delta pages forward, wrapping around at either end.
+func (p *layerPages) turn(n, delta int) {
+	pages := p.count(n)
+	p.page = ((p.page+delta)%pages + pages) % pages
+}
+
+// fit returns to the first page when the layer list shrank under it.
+func (p *layerPages) fit(n int) {
+	if p.page >= p.count(n) {
+		p.page = 0
+	}
+}
+
+// bounds returns the half-open range of layer indices on the current page.
+func (p *layerPages) bounds(n int) (int, int) {
+	lo := min(p.page*layersPerPage, n)
+	return lo, min(lo+layersPerPage, n)
+}
+
+// layer returns the index of the layer that digit (0 for key 1) selects on
+// the current page.
+func (p *layerPages) layer(n, digit int) (int, bool) {
+	if digit < 0 || digit >= layersPerPage {
+		return 0, false
+	}
+	lo, hi := p.bounds(n)
+	idx := lo + digit
+	return idx, idx < hi
+}
+
+// onPage reports whether layer idx sits on the current page and, if so,
+// which digit (0 for key 1) selects it.
+func (p *layerPages) onPage(n, idx int) (int, bool) {
+	lo, hi := p.bounds(n)
+	return idx - lo, idx >= lo && idx < hi
+}
@@ -0,0 +1,55 @@
+package ui
+
+import "testing"
+
+// reachable presses every digit on every page and reports which layer
+// indices the keys selected.
+func reachable(n int) map[int]bool {
+	var pages layerPages
+	seen := map[int]bool{}
+	for range pages.count(n) {
+		for digit := 0; digit < layersPerPage; digit++ {
+			if idx, ok := pages.layer(n, digit); ok {
+				seen[idx] = true
+			}
+		}
+		pages.turn(n, 1)
+	}
+	return seen
+}
+
+func TestLayerPagesReachEveryLayer(t *testing.T) {
+	for _, n := range []int{0, 1, 9, 10, 24, 40} {
+		seen := reachable(n)
+		if len(seen) != n {
+			t.Fatalf("%d layers: digit keys reached %d", n, len(seen))
+		}
+		for idx := range seen {
+			if idx < 0 || idx >= n {
+				t.Fatalf("%d layers: digit selected out-of-range layer %d", n, idx)
+			}
+		}
+	}
+}
+
+func TestLayerPagesTurnWrapsAndFits(t *testing.T) {
+	var pages layerPages
+	pages.turn(24, -1)
+	if pages.page != 2 {
+		t.Fatalf("turning back from the first page should wrap to the last, got %d", pages.page)
+	}
+	if idx, ok := pages.layer(24, 5); !ok || idx != 23 {
+		t.Fatalf("key 6 on the last page = %d, %v; want layer 23", idx, ok)
+	}
+	if _, ok := pages.layer(24, 6); ok {
+		t.Fatal("keys past the last layer should select nothing")
+	}
+	if digit, ok := pages.onPage(24, 20); !ok || digit != 2 {
+		t.Fatalf("layer 20 on page 3 = key %d, %v; want key 3", digit+1, ok)
+	}
+
+	pages.fit(9)
+	if pages.page != 0 {
+		t.Fatalf("a shrunken list should return to the first page, got %d", pages.page)
+	}
+}
@@ -0,0 +1,306 @@
+//go:build ebiten
+
+package ui
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"mad-ca/internal/core"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// MetricsPanel plots recent simulation telemetry in a strip beneath the
+// simulation view and HUD. Samples that share a group are drawn as lines on a
+// common chart; histograms are drawn as log-binned bar charts.
+type MetricsPanel struct {
+	provider core.MetricsProvider
+	width    int
+	capacity int
+
+	series     map[string]*metricSeries
+	groups     []*metricGroup
+	histograms []core.MetricHistogram
+
+	panel *ebiten.Image
+	pixel *ebiten.Image
+}
+
+type metricGroup struct {
+	name   string
+	series []*metricSeries
+}
+
+type metricSeries struct {
+	label  string
+	values []float64
+	start  int
+	count  int
+}
+
+// NewMetricsPanel constructs a panel for the provided simulation. It returns
+// nil when the simulation does not report metrics.
+func NewMetricsPanel(sim core.Sim, width int) *MetricsPanel {
+	provider, ok := sim.(core.MetricsProvider)
+	if !ok || width <= 0 {
+		return nil
+	}
+	p := &MetricsPanel{
+		provider: provider,
+		width:    width,
+		capacity: metricsHistoryTicks,
+		series:   map[string]*metricSeries{},
+	}
+	p.pixel = ebiten.NewImage(1, 1)
+	p.pixel.Fill(color.White)
+	p.Record()
+	return p
+}
+
+// Height reports the vertical space the panel occupies.
+func (p *MetricsPanel) Height() int {
+	if p == nil {
+		return 0
+	}
+	return metricsPanelHeight
+}
+
+// Record samples the provider and appends the values to the rolling history.
+func (p *MetricsPanel) Record() {
+	if p == nil {
+		return
+	}
+	snapshot := p.provider.MetricsSnapshot()
+	for _, sample := range snapshot.Samples {
+		s, ok := p.series[sample.Key]
+		if !ok {
+			s = &metricSeries{label: sample.Label, values: make([]float64, p.capacity)}
+			p.series[sample.Key] = s
+			group := p.groupFor(sample.Group)
+			group.series = append(group.series, s)
+		}
+		s.push(sample.Value)
+	}
+	p.histograms = snapshot.Histograms
+}
+
+// Clear drops the recorded history, e.g. after the simulation is reset.
+func (p *MetricsPanel) Clear() {
+	if p == nil {
+		return
+	}
+	for _, s := range p.series {
+		s.start = 0
+		s.count = 0
+	}
+	p.histograms = nil
+	p.Record()
+}
+
+// Draw paints the panel with its top edge at offsetY.
+func (p *MetricsPanel) Draw(screen *ebiten.Image, offsetY int) {
+	if p == nil {
+		return
+	}
+	if p.panel == nil || p.panel.Bounds().Dx() != p.width {
+		p.panel = ebiten.NewImage(p.width, metricsPanelHeight)
+	}
+	p.panel.Fill(color.RGBA{R: 12, G: 12, B: 16, A: 255})
+
+	charts := len(p.groups) + len(p.histograms)
+	if charts == 0 {
+		text.Draw(p.panel, "No metrics reported", basicfont.Face7x13, panelPadding, panelPadding+headerBaseline, color.RGBA{R: 160, G: 160, B: 170, A: 255})
+	} else {
+		slot := float64(p.width-panelPadding) / float64(charts)
+		for i, group := range p.groups {
+			x := float64(panelPadding) + float64(i)*slot
+			p.drawLineChart(group, x, slot-panelPadding)
+		}
+		for i, hist := range p.histograms {
+			x := float64(panelPadding) + float64(len(p.groups)+i)*slot
+			p.drawHistogram(hist, x, slot-panelPadding)
+		}
+	}
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(0, float64(offsetY))
+	screen.DrawImage(p.panel, op)
+}
+
+func (p *MetricsPanel) groupFor(name string) *metricGroup {
+	for _, g := range p.groups {
+		if g.name == name {
+			return g
+		}
+	}
+	g := &metricGroup{name: name}
+	p.groups = append(p.groups, g)
+	return g
+}
+
+func (p *MetricsPanel) drawLineChart(group *metricGroup, x, width float64) {
+	face := basicfont.Face7x13
+	top := float64(panelPadding)
+	text.Draw(p.panel, group.name, face, int(x), int(top)+headerBaseline-6, color.RGBA{R: 200, G: 200, B: 210, A: 255})
+
+	plotTop := top + metricsTitleHeight
+	plotHeight := float64(metricsPanelHeight-panelPadding) - plotTop
+	p.fillRect(x, plotTop, width, plotHeight, color.RGBA{R: 24, G: 24, B: 30, A: 255})
+
+	minVal, maxVal := math.Inf(1), math.Inf(-1)
+	for _, s := range group.series {
+		for i := 0; i < s.count; i++ {
+			v := s.at(i)
+			minVal = math.Min(minVal, v)
+			maxVal = math.Max(maxVal, v)
+		}
+	}
+	if math.IsInf(minVal, 1) {
+		return
+	}
+	if minVal > 0 {
+		minVal = 0
+	}
+	if maxVal <= minVal {
+		maxVal = minVal + 1
+	}
+	span := maxVal - minVal
+	step := width / float64(p.capacity-1)
+
+	for si, s := range group.series {
+		col := metricsSeriesColors[si%len(metricsSeriesColors)]
+		// Right-align the history so the newest sample sits at the chart edge.
+		offset := float64(p.capacity-s.count) * step
+		for i := 1; i < s.count; i++ {
+			y0 := plotTop + plotHeight - (s.at(i-1)-minVal)/span*plotHeight
+			y1 := plotTop + plotHeight - (s.at(i)-minVal)/span*plotHeight
+			p.strokeLine(x+offset+float64(i-1)*step, y0, x+offset+float64(i)*step, y1, col)
+		}
+		latest := "--"
+		if s.count > 0 {
+			latest = formatMetricValue(s.at(s.count - 1))
+		}
+		labelY := int(plotTop) + metricsLegendLine*(si+1)
+		text.Draw(p.panel, s.label+" "+latest, face, int(x)+4, labelY, col)
+	}
+
+	text.Draw(p.panel, formatMetricValue(maxVal), face, int(x+width)-metricsAxisLabelWidth, int(plotTop)+metricsLegendLine, color.RGBA{R: 120, G: 120, B: 130, A: 255})
+}
+
+func (p *MetricsPanel) drawHistogram(hist core.MetricHistogram, x, width float64) {
+	face := basicfont.Face7x13
+	top := float64(panelPadding)
+	text.Draw(p.panel, hist.Label, face, int(x), int(top)+headerBaseline-6, color.RGBA{R: 200, G: 200, B: 210, A: 255})
+
+	plotTop := top + metricsTitleHeight
+	plotHeight := float64(metricsPanelHeight-panelPadding) - plotTop
+	p.fillRect(x, plotTop, width, plotHeight, color.RGBA{R: 24, G: 24, B: 30, A: 255})
+
+	// Bucket sizes logarithmically so a single huge component doesn't squash
+	// the rest of the distribution: bucket b covers sizes [2^b, 2^(b+1)).
+	var buckets []int
+	for size, count := range hist.Bins {
+		if size <= 0 || count == 0 {
+			continue
+		}
+		b := int(math.Log2(float64(size)))
+		for len(buckets) <= b {
+			buckets = append(buckets, 0)
+		}
+		buckets[b] += count
+	}
+	if len(buckets) == 0 {
+		return
+	}
+	maxCount := 0
+	for _, c := range buckets {
+		if c > maxCount {
+			maxCount = c
+		}
+	}
+	barWidth := width / float64(len(buckets))
+	col := color.RGBA{R: 120, G: 190, B: 110, A: 255}
+	for b, c := range buckets {
+		if c == 0 {
+			continue
+		}
+		h := float64(c) / float64(maxCount) * (plotHeight - metricsLegendLine)
+		p.fillRect(x+float64(b)*barWidth+1, plotTop+plotHeight-h, math.Max(1, barWidth-2), h, col)
+	}
+	caption := "1.." + strconv.Itoa(1<<len(buckets)-1) + " max " + strconv.Itoa(maxCount)
+	text.Draw(p.panel, caption, face, int(x)+4, int(plotTop)+metricsLegendLine, color.RGBA{R: 160, G: 160, B: 170, A: 255})
+}
+
+func (p *MetricsPanel) fillRect(x, y, w, h float64, col color.RGBA) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(w, h)
+	op.GeoM.Translate(x, y)
+	op.ColorM.Scale(float64(col.R)/255.0, float64(col.G)/255.0, float64(col.B)/255.0, float64(col.A)/255.0)
+	p.panel.DrawImage(p.pixel, op)
+}
+
+func (p *MetricsPanel) strokeLine(x1, y1, x2, y2 float64, col color.RGBA) {
+	dx := x2 - x1
+	dy := y2 - y1
+	length := math.Hypot(dx, dy)
+	if length <= 1e-4 {
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(length, 1)
+	op.GeoM.Translate(0, -0.5)
+	op.GeoM.Rotate(math.Atan2(dy, dx))
+	op.GeoM.Translate(x1, y1)
+	op.ColorM.Scale(float64(col.R)/255.0, float64(col.G)/255.0, float64(col.B)/255.0, float64(col.A)/255.0)
+	p.panel.DrawImage(p.pixel, op)
+}
+
+func (s *metricSeries) push(v float64) {
+	capacity := len(s.values)
+	if s.count < capacity {
+		s.values[(s.start+s.count)%capacity] = v
+		s.count++
+		return
+	}
+	s.values[s.start] = v
+	s.start = (s.start + 1) % capacity
+}
+
+func (s *metricSeries) at(i int) float64 {
+	return s.values[(s.start+i)%len(s.values)]
+}
+
+func formatMetricValue(v float64) string {
+	abs := math.Abs(v)
+	switch {
+	case abs >= 10000:
+		return strconv.FormatFloat(v/1000, 'f', 1, 64) + "k"
+	case abs >= 100 || v == math.Trunc(v):
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	default:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	}
+}
+
+var metricsSeriesColors = []color.RGBA{
+	{R: 120, G: 200, B: 110, A: 255},
+	{R: 90, G: 160, B: 230, A: 255},
+	{R: 240, G: 170, B: 70, A: 255},
+	{R: 230, G: 100, B: 110, A: 255},
+	{R: 190, G: 140, B: 230, A: 255},
+}
+
+const (
+	metricsPanelHeight    = 170
+	metricsHistoryTicks   = 300
+	metricsTitleHeight    = 18
+	metricsLegendLine     = 14
+	metricsAxisLabelWidth = 42
+)
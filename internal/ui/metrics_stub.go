@@ -0,0 +1,23 @@
+//go:build !ebiten
+
+package ui
+
+import "mad-ca/internal/core"
+
+// MetricsPanel is a no-op placeholder for headless builds.
+type MetricsPanel struct{}
+
+// NewMetricsPanel returns nil in the headless build.
+func NewMetricsPanel(core.Sim, int) *MetricsPanel { return nil }
+
+// Height reports zero in the headless build.
+func (p *MetricsPanel) Height() int { return 0 }
+
+// Record is a no-op in the headless build.
+func (p *MetricsPanel) Record() {}
+
+// Clear is a no-op in the headless build.
+func (p *MetricsPanel) Clear() {}
+
+// Draw is a no-op in the headless build.
+func (p *MetricsPanel) Draw(any, int) {}
@@ -5,47 +5,35 @@ package ui
 import (
 	"image/color"
 	"math"
+	"strconv"
 
 	"mad-ca/internal/core"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
 )
 
-type maskProvider interface {
-	RainMask() []float32
-	VolcanoMask() []float32
-}
-
 type windFieldProvider interface {
 	WindVectorAt(x, y float64) (float64, float64)
 }
 
-type elevationFieldProvider interface {
-	ElevationField() []int16
-}
-
-type heatFieldProvider interface {
-	HeatField() []float32
-}
-
 // Overlay draws optional debugging visuals on top of the base simulation.
+// Scalar layers are discovered from core.FieldProvider and paged nine at a
+// time in the order the sim lists them: digit keys toggle the layers on the
+// current page, PgUp/PgDn (or [/]) turn the page, and L lists the page in the
+// legend. Tab cycles the focused layer, -/= adjust its opacity, and C cycles
+// its colormap. W toggles the wind vector field for sims that expose one.
 type Overlay struct {
-	sim         core.Sim
-	scale       int
-	showRain    bool
-	showVolcano bool
-	showWind    bool
-	showElev    bool
-	showHeat    bool
-	maskImg     *ebiten.Image
-	maskBuf     []byte
-
-	elevationImg *ebiten.Image
-	elevationBuf []byte
-
-	heatImg *ebiten.Image
-	heatBuf []byte
+	sim      core.Sim
+	scale    int
+	showWind bool
+	showList bool
+
+	layers []*overlayLayer
+	pages  layerPages
+	focus  int
 
 	pixel          *ebiten.Image
 	windSamples    []windSample
@@ -55,6 +43,22 @@ type Overlay struct {
 	windPixelSpan  float64
 }
 
+// overlayLayer tracks the presentation state of one discovered scalar field.
+type overlayLayer struct {
+	key     string
+	label   string
+	visible bool
+	opacity float64
+	// colormap indexes selectableColormaps; -1 selects the field's default.
+	colormap int
+
+	min float32
+	max float32
+
+	img *ebiten.Image
+	buf []byte
+}
+
 type windSample struct {
 	cx float64
 	cy float64
@@ -67,25 +71,62 @@ func NewOverlay(sim core.Sim, scale int) *Overlay {
 	o := &Overlay{sim: sim, scale: scale}
 	o.pixel = ebiten.NewImage(1, 1)
 	o.pixel.Fill(color.White)
+	o.syncLayers(o.fields())
 	return o
 }
 
+var overlayDigitKeys = [...]ebiten.Key{
+	ebiten.KeyDigit1, ebiten.KeyDigit2, ebiten.KeyDigit3,
+	ebiten.KeyDigit4, ebiten.KeyDigit5, ebiten.KeyDigit6,
+	ebiten.KeyDigit7, ebiten.KeyDigit8, ebiten.KeyDigit9,
+}
+
 // Update allows the overlay to update internal state.
 func (o *Overlay) Update() {
-	if inpututil.IsKeyJustPressed(ebiten.KeyDigit1) {
-		o.showRain = !o.showRain
+	o.syncLayers(o.fields())
+	if inpututil.IsKeyJustPressed(ebiten.KeyPageUp) || inpututil.IsKeyJustPressed(ebiten.KeyBracketLeft) {
+		o.turnPage(-1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyPageDown) || inpututil.IsKeyJustPressed(ebiten.KeyBracketRight) {
+		o.turnPage(1)
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyDigit2) {
-		o.showVolcano = !o.showVolcano
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		o.showList = !o.showList
+	}
+	for digit, key := range overlayDigitKeys {
+		if !inpututil.IsKeyJustPressed(key) {
+			continue
+		}
+		if i, ok := o.pages.layer(len(o.layers), digit); ok {
+			layer := o.layers[i]
+			layer.visible = !layer.visible
+			if layer.visible {
+				o.focus = i
+			}
+		}
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyDigit3) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyW) {
 		o.showWind = !o.showWind
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyDigit4) {
-		o.showElev = !o.showElev
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		o.cycleFocus()
+	}
+
+	layer := o.focusedLayer()
+	if layer == nil {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+		layer.opacity = clamp(layer.opacity-overlayOpacityStep, overlayMinOpacity, 1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+		layer.opacity = clamp(layer.opacity+overlayOpacityStep, overlayMinOpacity, 1)
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyDigit5) {
-		o.showHeat = !o.showHeat
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		layer.colormap++
+		if layer.colormap >= len(selectableColormaps) {
+			layer.colormap = -1
+		}
 	}
 }
 
@@ -100,43 +141,102 @@ func (o *Overlay) Draw(screen *ebiten.Image) {
 		scale = 1
 	}
 
+	fields := o.fields()
+	o.syncLayers(fields)
+	// Dense layers (terrain-like) go underneath so sparse intensity layers
+	// remain visible on top of them.
+	for _, dense := range [...]bool{true, false} {
+		for i, field := range fields {
+			if field.Dense == dense && o.layers[i].visible {
+				values := o.sim.(core.FieldProvider).Field(field.Key)
+				o.drawField(screen, o.layers[i], field, values, size, scale)
+			}
+		}
+	}
+
 	if o.showWind {
 		if provider, ok := o.sim.(windFieldProvider); ok {
 			o.drawWindField(screen, provider, size, scale)
 		}
 	}
 
-	if o.showElev {
-		if provider, ok := o.sim.(elevationFieldProvider); ok {
-			o.drawElevation(screen, provider.ElevationField(), size, scale)
-		}
+	o.drawLegend(screen, fields, size, scale)
+}
+
+func (o *Overlay) fields() []core.FieldInfo {
+	provider, ok := o.sim.(core.FieldProvider)
+	if !ok {
+		return nil
 	}
+	return provider.Fields()
+}
 
-	if o.showHeat {
-		if provider, ok := o.sim.(heatFieldProvider); ok {
-			o.drawHeat(screen, provider.HeatField(), size, scale)
+// syncLayers keeps the layer list aligned with the fields the sim currently
+// reports, preserving visibility and styling for keys that persist.
+func (o *Overlay) syncLayers(fields []core.FieldInfo) {
+	unchanged := len(fields) == len(o.layers)
+	for i := 0; unchanged && i < len(fields); i++ {
+		unchanged = fields[i].Key == o.layers[i].key
+	}
+	if unchanged {
+		return
+	}
+	existing := make(map[string]*overlayLayer, len(o.layers))
+	for _, layer := range o.layers {
+		existing[layer.key] = layer
+	}
+	layers := make([]*overlayLayer, len(fields))
+	for i, field := range fields {
+		layer, ok := existing[field.Key]
+		if !ok {
+			layer = &overlayLayer{key: field.Key, opacity: overlayDefaultOpacity, colormap: -1}
 		}
+		layer.label = field.Label
+		layers[i] = layer
 	}
+	o.layers = layers
+	o.pages.fit(len(o.layers))
+	if o.focus >= len(o.layers) {
+		o.focus = 0
+	}
+}
 
-	if provider, ok := o.sim.(maskProvider); ok {
-		total := size.W * size.H
-		if total == 0 {
+// turnPage moves the digit keys to another page of layers and lists it in
+// the legend, so the newly reachable layers can be seen before toggling.
+func (o *Overlay) turnPage(delta int) {
+	if o.pages.count(len(o.layers)) < 2 {
+		return
+	}
+	o.pages.turn(len(o.layers), delta)
+	o.showList = true
+}
+
+func (o *Overlay) focusedLayer() *overlayLayer {
+	if o.focus < 0 || o.focus >= len(o.layers) {
+		return nil
+	}
+	layer := o.layers[o.focus]
+	if !layer.visible {
+		return nil
+	}
+	return layer
+}
+
+func (o *Overlay) cycleFocus() {
+	for step := 1; step <= len(o.layers); step++ {
+		idx := (o.focus + step) % len(o.layers)
+		if o.layers[idx].visible {
+			o.focus = idx
 			return
 		}
-		if o.maskImg == nil || o.maskImg.Bounds().Dx() != size.W || o.maskImg.Bounds().Dy() != size.H {
-			o.maskImg = ebiten.NewImage(size.W, size.H)
-			o.maskBuf = make([]byte, 4*total)
-		} else if len(o.maskBuf) != 4*total {
-			o.maskBuf = make([]byte, 4*total)
-		}
+	}
+}
 
-		if o.showRain {
-			o.drawMask(screen, provider.RainMask(), color.RGBA{R: 64, G: 164, B: 223, A: 0})
-		}
-		if o.showVolcano {
-			o.drawMask(screen, provider.VolcanoMask(), color.RGBA{R: 255, G: 120, B: 40, A: 0})
-		}
+func (layer *overlayLayer) colormapFor(field core.FieldInfo) colormap {
+	if layer.colormap >= 0 && layer.colormap < len(selectableColormaps) {
+		return selectableColormaps[layer.colormap]
 	}
+	return lookupColormap(field.Colormap)
 }
 
 func (o *Overlay) drawWindField(screen *ebiten.Image, provider windFieldProvider, size core.Size, scale int) {
@@ -321,181 +421,198 @@ func (o *Overlay) drawLine(screen *ebiten.Image, x1, y1, x2, y2, thickness float
 	screen.DrawImage(o.pixel, op)
 }
 
-func (o *Overlay) drawMask(screen *ebiten.Image, mask []float32, tint color.RGBA) {
-	size := o.sim.Size()
+func (o *Overlay) drawField(screen *ebiten.Image, layer *overlayLayer, field core.FieldInfo, values []float32, size core.Size, scale int) {
 	total := size.W * size.H
-	if len(mask) != total {
+	if len(values) != total || total == 0 {
 		return
 	}
-	const (
-		maxAlpha      = 140.0
-		glowBase      = 0.35
-		glowRange     = 0.65
-		intensityBias = 0.75
-	)
+	if layer.img == nil || layer.img.Bounds().Dx() != size.W || layer.img.Bounds().Dy() != size.H {
+		layer.img = ebiten.NewImage(size.W, size.H)
+		layer.buf = make([]byte, 4*total)
+	} else if len(layer.buf) != 4*total {
+		layer.buf = make([]byte, 4*total)
+	}
 
-	for i := 0; i < total; i++ {
-		base := i * 4
-		intensity := float64(mask[i])
-		if intensity < 0 {
-			intensity = 0
-		}
-		if intensity > 1 {
-			intensity = 1
-		}
-		if intensity == 0 {
-			o.maskBuf[base+0] = 0
-			o.maskBuf[base+1] = 0
-			o.maskBuf[base+2] = 0
-			o.maskBuf[base+3] = 0
-			continue
+	minVal, maxVal := field.Min, field.Max
+	if minVal >= maxVal {
+		minVal, maxVal = values[0], values[0]
+		for _, v := range values {
+			if v < minVal {
+				minVal = v
+			}
+			if v > maxVal {
+				maxVal = v
+			}
 		}
-
-		alpha := uint8(math.Round(maxAlpha * math.Pow(intensity, intensityBias)))
-		glow := glowBase + glowRange*math.Sqrt(intensity)
-
-		o.maskBuf[base+0] = scaleColorComponent(tint.R, glow)
-		o.maskBuf[base+1] = scaleColorComponent(tint.G, glow)
-		o.maskBuf[base+2] = scaleColorComponent(tint.B, glow)
-		o.maskBuf[base+3] = alpha
 	}
-	o.maskImg.ReplacePixels(o.maskBuf)
-	op := &ebiten.DrawImageOptions{}
-	scale := o.scale
-	if scale <= 0 {
-		scale = 1
+	layer.min, layer.max = minVal, maxVal
+	span := float64(maxVal - minVal)
+	if span <= 0 {
+		span = 1
 	}
-	op.GeoM.Scale(float64(scale), float64(scale))
-	screen.DrawImage(o.maskImg, op)
-}
 
-func (o *Overlay) drawHeat(screen *ebiten.Image, field []float32, size core.Size, scale int) {
-	total := size.W * size.H
-	if len(field) != total || total == 0 {
-		return
-	}
-	if o.heatImg == nil || o.heatImg.Bounds().Dx() != size.W || o.heatImg.Bounds().Dy() != size.H {
-		o.heatImg = ebiten.NewImage(size.W, size.H)
-		o.heatBuf = make([]byte, 4*total)
-	} else if len(o.heatBuf) != 4*total {
-		o.heatBuf = make([]byte, 4*total)
-	}
+	const sparseGamma = 0.75
+	cmap := layer.colormapFor(field)
+	maxAlpha := 255 * layer.opacity
 
-	const alphaScale = 220.0
-	const alphaGamma = 0.6
+	for y := 0; y < size.H; y++ {
+		for x := 0; x < size.W; x++ {
+			idx := y*size.W + x
+			base := idx * 4
+			v := values[idx]
+			t := clamp01(float64(v-minVal) / span)
+
+			var alpha float64
+			if field.Dense {
+				// Shade by the steepest neighbour difference so relief stays
+				// readable under flat colour ramps.
+				maxDiff := float32(0)
+				if x > 0 {
+					maxDiff = max(maxDiff, absFloat32(v-values[idx-1]))
+				}
+				if x+1 < size.W {
+					maxDiff = max(maxDiff, absFloat32(v-values[idx+1]))
+				}
+				if y > 0 {
+					maxDiff = max(maxDiff, absFloat32(v-values[idx-size.W]))
+				}
+				if y+1 < size.H {
+					maxDiff = max(maxDiff, absFloat32(v-values[idx+size.W]))
+				}
+				slope := clamp01(float64(maxDiff) / span)
+				alpha = maxAlpha * (0.55 + 0.45*slope)
+			} else {
+				if t <= 0 {
+					layer.buf[base+0] = 0
+					layer.buf[base+1] = 0
+					layer.buf[base+2] = 0
+					layer.buf[base+3] = 0
+					continue
+				}
+				alpha = maxAlpha * math.Pow(t, sparseGamma)
+			}
 
-	for i := 0; i < total; i++ {
-		base := i * 4
-		intensity := clamp01(float64(field[i]))
-		if intensity <= 0 {
-			o.heatBuf[base+0] = 0
-			o.heatBuf[base+1] = 0
-			o.heatBuf[base+2] = 0
-			o.heatBuf[base+3] = 0
-			continue
+			col := cmap.at(t)
+			layer.buf[base+0] = col.R
+			layer.buf[base+1] = col.G
+			layer.buf[base+2] = col.B
+			layer.buf[base+3] = uint8(math.Round(clamp(alpha, 0, 255)))
 		}
-
-		col := heatColor(intensity)
-		alpha := uint8(math.Round(alphaScale * math.Pow(intensity, alphaGamma)))
-		o.heatBuf[base+0] = col.R
-		o.heatBuf[base+1] = col.G
-		o.heatBuf[base+2] = col.B
-		o.heatBuf[base+3] = alpha
 	}
 
-	o.heatImg.ReplacePixels(o.heatBuf)
+	layer.img.ReplacePixels(layer.buf)
 	op := &ebiten.DrawImageOptions{}
-	if scale <= 0 {
-		scale = 1
-	}
 	op.GeoM.Scale(float64(scale), float64(scale))
-	screen.DrawImage(o.heatImg, op)
+	screen.DrawImage(layer.img, op)
 }
 
-func (o *Overlay) drawElevation(screen *ebiten.Image, field []int16, size core.Size, scale int) {
-	total := size.W * size.H
-	if len(field) != total || total == 0 {
-		return
+// drawLegend lists the active overlays in the bottom-left corner of the
+// simulation view with their colour ramp, value range and opacity. With the
+// layer list open it also names the hidden layers on the current page.
+func (o *Overlay) drawLegend(screen *ebiten.Image, fields []core.FieldInfo, size core.Size, scale int) {
+	var rows []int
+	for i, layer := range o.layers {
+		if _, listed := o.pages.onPage(len(o.layers), i); layer.visible || (o.showList && listed) {
+			rows = append(rows, i)
+		}
+	}
+	lines := len(rows)
+	if o.showWind {
+		lines++
 	}
-	if o.elevationImg == nil || o.elevationImg.Bounds().Dx() != size.W || o.elevationImg.Bounds().Dy() != size.H {
-		o.elevationImg = ebiten.NewImage(size.W, size.H)
-		o.elevationBuf = make([]byte, 4*total)
-	} else if len(o.elevationBuf) != 4*total {
-		o.elevationBuf = make([]byte, 4*total)
+	header := ""
+	if o.showList && len(o.layers) > 0 {
+		header = o.pageHeader()
+		lines++
+	}
+	if lines == 0 {
+		return
 	}
 
-	minVal := field[0]
-	maxVal := field[0]
-	for _, v := range field {
-		if v < minVal {
-			minVal = v
-		}
-		if v > maxVal {
-			maxVal = v
+	face := basicfont.Face7x13
+	labelWidth := 0
+	for _, i := range rows {
+		if w := text.BoundString(face, o.legendLabel(i)).Dx(); w > labelWidth {
+			labelWidth = w
 		}
 	}
-	rangeVal := float64(maxVal - minVal)
-	if rangeVal == 0 {
-		rangeVal = 1
+	width := 2*legendPadding + labelWidth + legendGap + legendBarWidth + legendGap + legendInfoWidth
+	width = max(width, 2*legendPadding+text.BoundString(face, header).Dx())
+	height := 2*legendPadding + lines*legendLineHeight
+	top := size.H*scale - height - legendMargin
+	left := legendMargin
+	if top < 0 {
+		top = 0
 	}
-	slopeScale := 0.0
-	if maxVal > minVal {
-		slopeScale = 1.0 / rangeVal
+
+	o.fillRect(screen, float64(left), float64(top), float64(width), float64(height), color.RGBA{R: 10, G: 10, B: 14, A: 200})
+
+	y := top + legendPadding
+	if header != "" {
+		text.Draw(screen, header, face, left+legendPadding, y+legendBaseline, color.RGBA{R: 150, G: 150, B: 165, A: 255})
+		y += legendLineHeight
 	}
+	for _, i := range rows {
+		layer := o.layers[i]
+		field := fields[i]
+		if !layer.visible {
+			text.Draw(screen, o.legendLabel(i), face, left+legendPadding, y+legendBaseline, color.RGBA{R: 110, G: 110, B: 120, A: 255})
+			y += legendLineHeight
+			continue
+		}
+		labelCol := color.RGBA{R: 190, G: 190, B: 200, A: 255}
+		if i == o.focus {
+			labelCol = color.RGBA{R: 255, G: 230, B: 140, A: 255}
+		}
+		text.Draw(screen, o.legendLabel(i), face, left+legendPadding, y+legendBaseline, labelCol)
+
+		cmap := layer.colormapFor(field)
+		barX := left + legendPadding + labelWidth + legendGap
+		barY := y + (legendLineHeight-legendBarHeight)/2
+		for px := 0; px < legendBarWidth; px++ {
+			col := cmap.at(float64(px) / float64(legendBarWidth-1))
+			col.A = uint8(math.Round(255 * layer.opacity))
+			o.fillRect(screen, float64(barX+px), float64(barY), 1, legendBarHeight, col)
+		}
 
-	for y := 0; y < size.H; y++ {
-		for x := 0; x < size.W; x++ {
-			idx := y*size.W + x
-			base := idx * 4
-			normalized := clamp01(float64(field[idx]-minVal) / rangeVal)
-			col := elevationColor(normalized)
+		info := formatMetricValue(float64(layer.min)) + ".." + formatMetricValue(float64(layer.max)) +
+			" " + strconv.Itoa(int(math.Round(layer.opacity*100))) + "% " + cmap.name
+		text.Draw(screen, info, face, barX+legendBarWidth+legendGap, y+legendBaseline, color.RGBA{R: 150, G: 150, B: 165, A: 255})
+		y += legendLineHeight
+	}
+	if o.showWind {
+		text.Draw(screen, "[W] Wind", face, left+legendPadding, y+legendBaseline, color.RGBA{R: 190, G: 190, B: 200, A: 255})
+	}
+}
 
-			alpha := float64(col.A)
-			if slopeScale > 0 {
-				baseVal := int(field[idx])
-				maxDiff := 0
-				if x > 0 {
-					diff := absInt(baseVal - int(field[idx-1]))
-					if diff > maxDiff {
-						maxDiff = diff
-					}
-				}
-				if x+1 < size.W {
-					diff := absInt(baseVal - int(field[idx+1]))
-					if diff > maxDiff {
-						maxDiff = diff
-					}
-				}
-				if y > 0 {
-					diff := absInt(baseVal - int(field[idx-size.W]))
-					if diff > maxDiff {
-						maxDiff = diff
-					}
-				}
-				if y+1 < size.H {
-					diff := absInt(baseVal - int(field[idx+size.W]))
-					if diff > maxDiff {
-						maxDiff = diff
-					}
-				}
-				slope := clamp01(float64(maxDiff) * slopeScale)
-				alpha *= 0.55 + 0.45*slope
-			}
+// legendLabel prefixes a layer with the digit that toggles it, or with its
+// page when it lies on another one.
+func (o *Overlay) legendLabel(i int) string {
+	if digit, ok := o.pages.onPage(len(o.layers), i); ok {
+		return "[" + strconv.Itoa(digit+1) + "] " + o.layers[i].label
+	}
+	return "[p" + strconv.Itoa(i/layersPerPage+1) + "] " + o.layers[i].label
+}
 
-			o.elevationBuf[base+0] = col.R
-			o.elevationBuf[base+1] = col.G
-			o.elevationBuf[base+2] = col.B
-			o.elevationBuf[base+3] = uint8(math.Round(clamp(alpha, 0, 255)))
-		}
+// pageHeader names the layers the digit keys currently reach.
+func (o *Overlay) pageHeader() string {
+	lo, hi := o.pages.bounds(len(o.layers))
+	header := "Layers " + strconv.Itoa(lo+1) + "-" + strconv.Itoa(hi) + " of " + strconv.Itoa(len(o.layers))
+	if o.pages.count(len(o.layers)) > 1 {
+		header += " [PgUp/PgDn]"
 	}
+	return header
+}
 
-	o.elevationImg.ReplacePixels(o.elevationBuf)
-	op := &ebiten.DrawImageOptions{}
-	if scale <= 0 {
-		scale = 1
+func (o *Overlay) fillRect(screen *ebiten.Image, x, y, w, h float64, col color.RGBA) {
+	if o.pixel == nil || w <= 0 || h <= 0 {
+		return
 	}
-	op.GeoM.Scale(float64(scale), float64(scale))
-	screen.DrawImage(o.elevationImg, op)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(w, h)
+	op.GeoM.Translate(x, y)
+	op.ColorM.Scale(float64(col.R)/255.0, float64(col.G)/255.0, float64(col.B)/255.0, float64(col.A)/255.0)
+	screen.DrawImage(o.pixel, op)
 }
 
 func interpolateColor(t float64) color.RGBA {
@@ -517,71 +634,6 @@ func clamp01(v float64) float64 {
 	return v
 }
 
-func scaleColorComponent(value uint8, factor float64) uint8 {
-	scaled := math.Round(float64(value) * factor)
-	if scaled < 0 {
-		return 0
-	}
-	if scaled > 255 {
-		return 255
-	}
-	return uint8(scaled)
-}
-
-func elevationColor(t float64) color.RGBA {
-	t = clamp01(t)
-	stops := []struct {
-		t   float64
-		col color.RGBA
-	}{
-		{0.0, color.RGBA{R: 40, G: 60, B: 120, A: 150}},
-		{0.25, color.RGBA{R: 70, G: 105, B: 160, A: 165}},
-		{0.5, color.RGBA{R: 90, G: 150, B: 100, A: 185}},
-		{0.75, color.RGBA{R: 190, G: 160, B: 80, A: 205}},
-		{1.0, color.RGBA{R: 240, G: 235, B: 215, A: 215}},
-	}
-	for i := 1; i < len(stops); i++ {
-		curr := stops[i]
-		if t <= curr.t {
-			prev := stops[i-1]
-			span := curr.t - prev.t
-			var local float64
-			if span > 0 {
-				local = (t - prev.t) / span
-			}
-			return lerpRGBA(prev.col, curr.col, clamp01(local))
-		}
-	}
-	return stops[len(stops)-1].col
-}
-
-func heatColor(t float64) color.RGBA {
-	t = clamp01(t)
-	stops := []struct {
-		t   float64
-		col color.RGBA
-	}{
-		{0.0, color.RGBA{R: 30, G: 0, B: 40, A: 0}},
-		{0.3, color.RGBA{R: 110, G: 20, B: 80, A: 0}},
-		{0.55, color.RGBA{R: 200, G: 60, B: 35, A: 0}},
-		{0.8, color.RGBA{R: 255, G: 140, B: 30, A: 0}},
-		{1.0, color.RGBA{R: 255, G: 235, B: 200, A: 0}},
-	}
-	for i := 1; i < len(stops); i++ {
-		curr := stops[i]
-		if t <= curr.t {
-			prev := stops[i-1]
-			span := curr.t - prev.t
-			var local float64
-			if span > 0 {
-				local = (t - prev.t) / span
-			}
-			return lerpRGBA(prev.col, curr.col, clamp01(local))
-		}
-	}
-	return stops[len(stops)-1].col
-}
-
 func lerpRGBA(a, b color.RGBA, t float64) color.RGBA {
 	t = clamp01(t)
 	return color.RGBA{
@@ -596,13 +648,6 @@ func lerpComponent(a, b uint8, t float64) uint8 {
 	return uint8(math.Round(float64(a) + (float64(b)-float64(a))*t))
 }
 
-func absInt(v int) int {
-	if v < 0 {
-		return -v
-	}
-	return v
-}
-
 func clamp(v, min, max float64) float64 {
 	if v < min {
 		return min
@@ -612,3 +657,25 @@ func clamp(v, min, max float64) float64 {
 	}
 	return v
 }
+
+func absFloat32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+const (
+	overlayDefaultOpacity = 0.6
+	overlayOpacityStep    = 0.1
+	overlayMinOpacity     = 0.1
+
+	legendMargin     = 6
+	legendPadding    = 6
+	legendLineHeight = 16
+	legendBaseline   = 12
+	legendGap        = 8
+	legendBarWidth   = 64
+	legendBarHeight  = 8
+	legendInfoWidth  = 150
+)
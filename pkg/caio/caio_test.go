@@ -0,0 +1,110 @@
+package caio
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWriteNPYHeaderAndData(t *testing.T) {
+	var buf bytes.Buffer
+	data := []int16{1, -2, 3, -4, 5, -6}
+	if err := WriteNPY(&buf, []int{2, 3}, data); err != nil {
+		t.Fatalf("WriteNPY: %v", err)
+	}
+	out := buf.Bytes()
+	if !bytes.HasPrefix(out, []byte("\x93NUMPY\x01\x00")) {
+		t.Fatalf("missing npy magic/version: %q", out[:8])
+	}
+	headerLen := int(binary.LittleEndian.Uint16(out[8:10]))
+	if (10+headerLen)%npyHeaderAlign != 0 {
+		t.Fatalf("header not aligned: total prefix %d", 10+headerLen)
+	}
+	header := string(out[10 : 10+headerLen])
+	if !strings.Contains(header, "'descr': '<i2'") || !strings.Contains(header, "'shape': (2, 3)") {
+		t.Fatalf("unexpected header %q", header)
+	}
+	if !strings.HasSuffix(header, "\n") {
+		t.Fatal("header must end with newline")
+	}
+	body := out[10+headerLen:]
+	if len(body) != len(data)*2 {
+		t.Fatalf("expected %d data bytes, got %d", len(data)*2, len(body))
+	}
+	for i, want := range data {
+		if got := int16(binary.LittleEndian.Uint16(body[i*2:])); got != want {
+			t.Fatalf("element %d: expected %d, got %d", i, want, got)
+		}
+	}
+}
+
+func TestWriteNPYFloat32AndShapeMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteNPY(&buf, []int{3}, []float32{0.5, -1, 2}); err != nil {
+		t.Fatalf("WriteNPY: %v", err)
+	}
+	out := buf.Bytes()
+	headerLen := int(binary.LittleEndian.Uint16(out[8:10]))
+	header := string(out[10 : 10+headerLen])
+	if !strings.Contains(header, "'descr': '<f4'") || !strings.Contains(header, "'shape': (3,)") {
+		t.Fatalf("unexpected header %q", header)
+	}
+	if got := math.Float32frombits(binary.LittleEndian.Uint32(out[10+headerLen+4:])); got != -1 {
+		t.Fatalf("expected second element -1, got %v", got)
+	}
+
+	if err := WriteNPY(io.Discard, []int{2, 2}, []uint8{1, 2, 3}); err == nil {
+		t.Fatal("expected error for shape/length mismatch")
+	}
+}
+
+func TestNPZBundlesArrays(t *testing.T) {
+	var buf bytes.Buffer
+	z := NewNPZWriter(&buf)
+	if err := WriteNPZArray(z, "ground", []int{1, 2}, []uint8{1, 2}); err != nil {
+		t.Fatalf("ground: %v", err)
+	}
+	if err := WriteNPZArray(z, "heat.npy", []int{1, 2}, []float32{0, 1}); err != nil {
+		t.Fatalf("heat: %v", err)
+	}
+	if err := z.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if strings.Join(names, ",") != "ground.npy,heat.npy" {
+		t.Fatalf("unexpected members %v", names)
+	}
+}
+
+func TestSeriesWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewSeriesWriter(&buf, "tick", []string{"grass", "trees"})
+	if err != nil {
+		t.Fatalf("NewSeriesWriter: %v", err)
+	}
+	if err := w.WriteRow(0, []float64{12, 0.5}); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+	if err := w.WriteRow(1, []float64{math.NaN()}); err != nil {
+		t.Fatalf("WriteRow: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	want := "tick,grass,trees\n0,12,0.5\n1,,\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,47 @@
+package caio
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+)
+
+// SeriesWriter writes a metric time series as CSV: a header naming the index
+// column and each series, followed by one row per tick.
+type SeriesWriter struct {
+	w       *csv.Writer
+	columns int
+	row     []string
+}
+
+// NewSeriesWriter writes the header row and returns a writer for the series
+// named in columns. index labels the first column, e.g. "tick".
+func NewSeriesWriter(w io.Writer, index string, columns []string) (*SeriesWriter, error) {
+	cw := csv.NewWriter(w)
+	header := append([]string{index}, columns...)
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+	return &SeriesWriter{w: cw, columns: len(columns), row: make([]string, len(header))}, nil
+}
+
+// WriteRow appends one row. Values beyond the declared columns are dropped and
+// missing trailing values are left empty. NaN values are written as empty
+// cells so spreadsheet and pandas readers treat them as missing.
+func (s *SeriesWriter) WriteRow(index int64, values []float64) error {
+	s.row[0] = strconv.FormatInt(index, 10)
+	for i := 0; i < s.columns; i++ {
+		s.row[i+1] = ""
+		if i < len(values) && !math.IsNaN(values[i]) {
+			s.row[i+1] = strconv.FormatFloat(values[i], 'g', -1, 64)
+		}
+	}
+	return s.w.Write(s.row)
+}
+
+// Flush writes any buffered rows and reports the first write error.
+func (s *SeriesWriter) Flush() error {
+	s.w.Flush()
+	return s.w.Error()
+}
@@ -0,0 +1,101 @@
+// Package caio writes simulation grids and telemetry in formats that common
+// analysis tooling reads directly: NumPy .npy arrays, .npz bundles of arrays,
+// and CSV time series.
+package caio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Element enumerates the array element types the NumPy writers support.
+type Element interface {
+	uint8 | int16 | float32
+}
+
+var npyMagic = []byte("\x93NUMPY")
+
+// npyHeaderAlign matches NumPy's own writer so arrays stay memory-mappable.
+const npyHeaderAlign = 64
+
+// WriteNPY writes data as a version 1.0 .npy array with the given shape in C
+// (row-major) order. The product of shape must equal len(data).
+func WriteNPY[T Element](w io.Writer, shape []int, data []T) error {
+	count := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			return fmt.Errorf("caio: negative dimension in shape %v", shape)
+		}
+		count *= dim
+	}
+	if count != len(data) {
+		return fmt.Errorf("caio: shape %v holds %d elements, got %d", shape, count, len(data))
+	}
+
+	var buf bytes.Buffer
+	header := npyHeader(npyDescr(data), shape)
+	buf.Write(npyMagic)
+	buf.Write([]byte{1, 0})
+	var lenBytes [2]byte
+	binary.LittleEndian.PutUint16(lenBytes[:], uint16(len(header)))
+	buf.Write(lenBytes[:])
+	buf.WriteString(header)
+
+	switch values := any(data).(type) {
+	case []uint8:
+		buf.Write(values)
+	case []int16:
+		var scratch [2]byte
+		for _, v := range values {
+			binary.LittleEndian.PutUint16(scratch[:], uint16(v))
+			buf.Write(scratch[:])
+		}
+	case []float32:
+		var scratch [4]byte
+		for _, v := range values {
+			binary.LittleEndian.PutUint32(scratch[:], math.Float32bits(v))
+			buf.Write(scratch[:])
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// npyDescr reports the NumPy dtype descriptor for the element type.
+func npyDescr[T Element](data []T) string {
+	switch any(data).(type) {
+	case []int16:
+		return "<i2"
+	case []float32:
+		return "<f4"
+	default:
+		return "|u1"
+	}
+}
+
+// npyHeader builds the dictionary literal padded with spaces and terminated
+// by a newline so that the magic, version, length and header together are a
+// multiple of npyHeaderAlign bytes.
+func npyHeader(descr string, shape []int) string {
+	dims := make([]string, len(shape))
+	for i, dim := range shape {
+		dims[i] = strconv.Itoa(dim)
+	}
+	shapeText := "(" + strings.Join(dims, ", ")
+	if len(shape) == 1 {
+		shapeText += ","
+	}
+	shapeText += ")"
+
+	header := "{'descr': '" + descr + "', 'fortran_order': False, 'shape': " + shapeText + ", }"
+	total := len(npyMagic) + 2 + 2 + len(header) + 1
+	if rem := total % npyHeaderAlign; rem != 0 {
+		header += strings.Repeat(" ", npyHeaderAlign-rem)
+	}
+	return header + "\n"
+}
@@ -0,0 +1,41 @@
+package caio
+
+import (
+	"archive/zip"
+	"io"
+	"strings"
+)
+
+// NPZWriter bundles several arrays into a single .npz archive, the format
+// written by numpy.savez_compressed and read by numpy.load.
+type NPZWriter struct {
+	zw *zip.Writer
+}
+
+// NewNPZWriter starts an archive on w. Call Close to finish it.
+func NewNPZWriter(w io.Writer) *NPZWriter {
+	return &NPZWriter{zw: zip.NewWriter(w)}
+}
+
+// Create opens the archive member for the named array. The returned writer is
+// valid until the next Create or Close call; write the array with WriteNPY.
+func (z *NPZWriter) Create(name string) (io.Writer, error) {
+	if !strings.HasSuffix(name, ".npy") {
+		name += ".npy"
+	}
+	return z.zw.Create(name)
+}
+
+// WriteNPZArray adds data to the archive under name with the given shape.
+func WriteNPZArray[T Element](z *NPZWriter, name string, shape []int, data []T) error {
+	w, err := z.Create(name)
+	if err != nil {
+		return err
+	}
+	return WriteNPY(w, shape, data)
+}
+
+// Close writes the archive directory. It does not close the underlying writer.
+func (z *NPZWriter) Close() error {
+	return z.zw.Close()
+}